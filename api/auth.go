@@ -0,0 +1,120 @@
+package api
+
+import (
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/kubemq-io/kubemq-targets/config"
+	"github.com/labstack/echo/v4"
+)
+
+const bearerPrefix = "Bearer "
+
+// roleLevels orders the api roles, a role is allowed the endpoints of the lower roles
+var roleLevels = map[string]int{
+	config.ApiRoleRead:    1,
+	config.ApiRoleRequest: 2,
+	config.ApiRoleAdmin:   3,
+}
+
+type auth struct {
+	tokens      map[string]string
+	clientRoles map[string]string
+}
+
+func newAuth(cfg *config.Config) *auth {
+	a := &auth{
+		tokens:      map[string]string{},
+		clientRoles: cfg.Api.ClientRoles,
+	}
+	for role, token := range map[string]string{
+		config.ApiRoleRead:    cfg.Api.ReadToken,
+		config.ApiRoleRequest: cfg.Api.RequestToken,
+		config.ApiRoleAdmin:   cfg.ApiAuthToken,
+	} {
+		if token != "" {
+			a.tokens[role] = token
+		}
+	}
+	return a
+}
+
+// enabled returns true when any api credential is configured
+func (a *auth) enabled() bool {
+	return len(a.tokens) > 0 || len(a.clientRoles) > 0
+}
+
+// role returns the role granted by the request bearer token, or by the verified client certificate when the request
+// has no valid bearer token
+func (a *auth) role(r *http.Request) string {
+	header := r.Header.Get(echo.HeaderAuthorization)
+	if strings.HasPrefix(header, bearerPrefix) {
+		token := []byte(strings.TrimPrefix(header, bearerPrefix))
+		role := ""
+		for tokenRole, roleToken := range a.tokens {
+			if subtle.ConstantTimeCompare(token, []byte(roleToken)) == 1 && roleLevels[tokenRole] > roleLevels[role] {
+				role = tokenRole
+			}
+		}
+		if role != "" {
+			return role
+		}
+	}
+	if r.TLS != nil && len(r.TLS.VerifiedChains) > 0 {
+		return a.clientRoles[r.TLS.VerifiedChains[0][0].Subject.CommonName]
+	}
+	return ""
+}
+
+// authorize allows the endpoint to requests granted role or higher. Without any configured credential the read and
+// request endpoints are open, as the admin endpoints require a credential they are disabled
+func (a *auth) authorize(role string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !a.enabled() {
+				if role == config.ApiRoleAdmin {
+					return jsonError(c, http.StatusForbidden, fmt.Errorf("bindings management is disabled, no api auth token is configured"))
+				}
+				return next(c)
+			}
+			granted := a.role(c.Request())
+			if granted == "" {
+				return jsonError(c, http.StatusUnauthorized, fmt.Errorf("invalid or missing api credentials"))
+			}
+			if roleLevels[granted] < roleLevels[role] {
+				return jsonError(c, http.StatusForbidden, fmt.Errorf("api role %s is not allowed, %s role is required", granted, role))
+			}
+			return next(c)
+		}
+	}
+}
+
+// newTLSConfig returns the api server TLS config, client certificates signed by the client ca are verified when given
+func newTLSConfig(cfg config.ApiConfig) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("error loading api certificate, %w", err)
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if cfg.ClientCAFile != "" {
+		ca, err := ioutil.ReadFile(cfg.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("error loading api client ca, %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("error loading api client ca, no valid certificates found")
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+	return tlsConfig, nil
+}
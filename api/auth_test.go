@@ -0,0 +1,184 @@
+package api
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kubemq-io/kubemq-targets/config"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func newClientCertState(commonName string) *tls.ConnectionState {
+	return &tls.ConnectionState{
+		VerifiedChains: [][]*x509.Certificate{
+			{
+				{Subject: pkix.Name{CommonName: commonName}},
+			},
+		},
+	}
+}
+
+func newAuthConfig() *config.Config {
+	return &config.Config{
+		ApiAuthToken: "admin-token",
+		Api: config.ApiConfig{
+			ReadToken:    "read-token",
+			RequestToken: "request-token",
+			ClientRoles: map[string]string{
+				"reader": config.ApiRoleRead,
+				"admin":  config.ApiRoleAdmin,
+			},
+		},
+	}
+}
+
+func TestAuth_Role(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		tls      *tls.ConnectionState
+		wantRole string
+	}{
+		{
+			name:     "read token",
+			header:   "Bearer read-token",
+			wantRole: config.ApiRoleRead,
+		},
+		{
+			name:     "request token",
+			header:   "Bearer request-token",
+			wantRole: config.ApiRoleRequest,
+		},
+		{
+			name:     "admin token",
+			header:   "Bearer admin-token",
+			wantRole: config.ApiRoleAdmin,
+		},
+		{
+			name:     "invalid token",
+			header:   "Bearer bad-token",
+			wantRole: "",
+		},
+		{
+			name:     "not a bearer header",
+			header:   "Basic admin-token",
+			wantRole: "",
+		},
+		{
+			name:     "client certificate",
+			tls:      newClientCertState("reader"),
+			wantRole: config.ApiRoleRead,
+		},
+		{
+			name:     "unknown client certificate",
+			tls:      newClientCertState("unknown"),
+			wantRole: "",
+		},
+		{
+			name:     "unverified client certificate",
+			tls:      &tls.ConnectionState{},
+			wantRole: "",
+		},
+		{
+			name:     "invalid token falls back to client certificate",
+			header:   "Bearer bad-token",
+			tls:      newClientCertState("admin"),
+			wantRole: config.ApiRoleAdmin,
+		},
+		{
+			name:     "valid token takes precedence over client certificate",
+			header:   "Bearer read-token",
+			tls:      newClientCertState("admin"),
+			wantRole: config.ApiRoleRead,
+		},
+	}
+	a := newAuth(newAuthConfig())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/bindings", nil)
+			if tt.header != "" {
+				r.Header.Set(echo.HeaderAuthorization, tt.header)
+			}
+			r.TLS = tt.tls
+			require.Equal(t, tt.wantRole, a.role(r))
+		})
+	}
+}
+
+func TestAuth_Authorize(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      *config.Config
+		role     string
+		header   string
+		wantCode int
+	}{
+		{
+			name:     "no credentials configured - read allowed",
+			cfg:      &config.Config{},
+			role:     config.ApiRoleRead,
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "no credentials configured - admin disabled",
+			cfg:      &config.Config{},
+			role:     config.ApiRoleAdmin,
+			header:   "Bearer admin-token",
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:     "missing credentials",
+			cfg:      newAuthConfig(),
+			role:     config.ApiRoleRead,
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "invalid token",
+			cfg:      newAuthConfig(),
+			role:     config.ApiRoleRead,
+			header:   "Bearer bad-token",
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "lower role",
+			cfg:      newAuthConfig(),
+			role:     config.ApiRoleRequest,
+			header:   "Bearer read-token",
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:     "same role",
+			cfg:      newAuthConfig(),
+			role:     config.ApiRoleRequest,
+			header:   "Bearer request-token",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "higher role",
+			cfg:      newAuthConfig(),
+			role:     config.ApiRoleRead,
+			header:   "Bearer admin-token",
+			wantCode: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.GET("/", func(c echo.Context) error {
+				return c.String(http.StatusOK, "ok")
+			}, newAuth(tt.cfg).authorize(tt.role))
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				r.Header.Set(echo.HeaderAuthorization, tt.header)
+			}
+			w := httptest.NewRecorder()
+			e.ServeHTTP(w, r)
+			require.Equal(t, tt.wantCode, w.Code)
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/kubemq-io/kubemq-targets/binding"
+	"github.com/kubemq-io/kubemq-targets/config"
+	"github.com/kubemq-io/kubemq-targets/pkg/journal"
+	"github.com/kubemq-io/kubemq-targets/types"
+	"github.com/labstack/echo/v4"
+)
+
+type errorResponse struct {
+	Error string
+}
+
+func jsonError(c echo.Context, code int, err error) error {
+	return c.JSONPretty(code, errorResponse{Error: err.Error()}, "\t")
+}
+
+// bindingError maps a binding service error to its http status
+func bindingError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, binding.ErrBindingNotFound):
+		return jsonError(c, http.StatusNotFound, err)
+	case errors.Is(err, binding.ErrBindingExists):
+		return jsonError(c, http.StatusConflict, err)
+	case errors.Is(err, binding.ErrInvalidBinding), errors.Is(err, binding.ErrInvalidReplay):
+		return jsonError(c, http.StatusBadRequest, err)
+	case errors.Is(err, binding.ErrNotRunning), errors.Is(err, binding.ErrBindingRunning), errors.Is(err, binding.ErrBindingPaused),
+		errors.Is(err, binding.ErrBindingChanged):
+		return jsonError(c, http.StatusConflict, err)
+	case errors.Is(err, binding.ErrJournalDisabled):
+		return jsonError(c, http.StatusNotFound, err)
+	default:
+		return jsonError(c, http.StatusInternalServerError, err)
+	}
+}
+
+func (s *Server) bindBindingConfig(c echo.Context) (config.BindingConfig, error) {
+	cfg := config.BindingConfig{}
+	if err := c.Bind(&cfg); err != nil {
+		return cfg, fmt.Errorf("invalid binding config, %s", err.Error())
+	}
+	return cfg, nil
+}
+
+func (s *Server) createBinding(c echo.Context) error {
+	cfg, err := s.bindBindingConfig(c)
+	if err != nil {
+		return jsonError(c, http.StatusBadRequest, err)
+	}
+	if err := s.bindingService.CreateBinding(cfg); err != nil {
+		return bindingError(c, err)
+	}
+	return c.JSONPretty(http.StatusCreated, cfg, "\t")
+}
+
+func (s *Server) updateBinding(c echo.Context) error {
+	cfg, err := s.bindBindingConfig(c)
+	if err != nil {
+		return jsonError(c, http.StatusBadRequest, err)
+	}
+	if cfg.Name != "" && cfg.Name != c.Param("name") {
+		return jsonError(c, http.StatusBadRequest, fmt.Errorf("binding name cannot be changed"))
+	}
+	if err := s.bindingService.UpdateBinding(c.Param("name"), cfg); err != nil {
+		return bindingError(c, err)
+	}
+	cfg.Name = c.Param("name")
+	return c.JSONPretty(http.StatusOK, cfg, "\t")
+}
+
+func (s *Server) deleteBinding(c echo.Context) error {
+	if err := s.bindingService.DeleteBinding(c.Param("name")); err != nil {
+		return bindingError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (s *Server) retryBinding(c echo.Context) error {
+	if err := s.bindingService.RetryBinding(c.Param("name")); err != nil {
+		return bindingError(c, err)
+	}
+	return c.NoContent(http.StatusAccepted)
+}
+
+func (s *Server) pauseBinding(c echo.Context) error {
+	if err := s.bindingService.PauseBinding(c.Param("name")); err != nil {
+		return bindingError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (s *Server) resumeBinding(c echo.Context) error {
+	if err := s.bindingService.ResumeBinding(c.Param("name")); err != nil {
+		return bindingError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// parseJournalFilter reads the status, since, until (RFC3339) and limit query params
+func parseJournalFilter(c echo.Context) (journal.Filter, error) {
+	filter := journal.Filter{
+		Status: c.QueryParam("status"),
+	}
+	switch filter.Status {
+	case "", types.ActivityStatusSuccess, types.ActivityStatusError:
+	default:
+		return filter, fmt.Errorf("invalid status %s, expected %s or %s", filter.Status, types.ActivityStatusSuccess, types.ActivityStatusError)
+	}
+	var err error
+	if since := c.QueryParam("since"); since != "" {
+		if filter.Since, err = time.Parse(time.RFC3339, since); err != nil {
+			return filter, fmt.Errorf("invalid since value, %w", err)
+		}
+	}
+	if until := c.QueryParam("until"); until != "" {
+		if filter.Until, err = time.Parse(time.RFC3339, until); err != nil {
+			return filter, fmt.Errorf("invalid until value, %w", err)
+		}
+	}
+	if limit := c.QueryParam("limit"); limit != "" {
+		if filter.Limit, err = strconv.Atoi(limit); err != nil || filter.Limit < 0 {
+			return filter, fmt.Errorf("invalid limit value %s", limit)
+		}
+	}
+	return filter, nil
+}
+
+func (s *Server) bindingActivity(c echo.Context) error {
+	filter, err := parseJournalFilter(c)
+	if err != nil {
+		return jsonError(c, http.StatusBadRequest, err)
+	}
+	list, err := s.bindingService.Activity(c.Param("name"), filter)
+	if err != nil {
+		return bindingError(c, err)
+	}
+	if list == nil {
+		list = []*types.Activity{}
+	}
+	return c.JSONPretty(http.StatusOK, list, "\t")
+}
+
+func (s *Server) replayBinding(c echo.Context) error {
+	req := &binding.ReplayRequest{}
+	if err := c.Bind(req); err != nil {
+		return jsonError(c, http.StatusBadRequest, fmt.Errorf("invalid replay request, %s", err.Error()))
+	}
+	result, err := s.bindingService.Replay(c.Request().Context(), c.Param("name"), req)
+	if err != nil {
+		return bindingError(c, err)
+	}
+	return c.JSONPretty(http.StatusOK, result, "\t")
+}
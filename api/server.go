@@ -3,9 +3,11 @@ package api
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/kubemq-io/kubemq-targets/binding"
+	"github.com/kubemq-io/kubemq-targets/config"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 )
@@ -13,30 +15,55 @@ import (
 type Server struct {
 	echoWebServer  *echo.Echo
 	bindingService *binding.Service
+	auth           *auth
 }
 
-func Start(ctx context.Context, port int, bs *binding.Service) (*Server, error) {
+// newServer returns the api server with its middlewares and routes, ready to be started
+func newServer(cfg *config.Config, bs *binding.Service) *Server {
 	s := &Server{
 		echoWebServer:  echo.New(),
 		bindingService: bs,
+		auth:           newAuth(cfg),
 	}
+	corsOrigins := cfg.Api.CorsOrigins
+	if len(corsOrigins) == 0 {
+		corsOrigins = []string{"*"}
+	}
+	read := s.auth.authorize(config.ApiRoleRead)
+	admin := s.auth.authorize(config.ApiRoleAdmin)
 	s.echoWebServer.Use(middleware.Recover())
-	s.echoWebServer.Use(middleware.CORS())
+	s.echoWebServer.Use(middleware.CORSWithConfig(middleware.CORSConfig{
+		AllowOrigins: corsOrigins,
+		AllowHeaders: []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization},
+	}))
 	s.echoWebServer.HideBanner = true
 
 	s.echoWebServer.GET("/health", func(c echo.Context) error {
 		return c.String(200, "ok")
 	})
 	s.echoWebServer.GET("/ready", func(c echo.Context) error {
-		return c.String(200, "ready")
+		ready, bindings := s.bindingService.Ready()
+		code := http.StatusOK
+		if !ready {
+			code = http.StatusServiceUnavailable
+		}
+		return c.JSONPretty(code, struct {
+			Ready    bool                 `json:"ready"`
+			Bindings []*binding.Readiness `json:"bindings"`
+		}{
+			Ready:    ready,
+			Bindings: bindings,
+		}, "\t")
 	})
-	s.echoWebServer.GET("/metrics", echo.WrapHandler(s.bindingService.PrometheusHandler()))
+	s.echoWebServer.GET("/metrics", echo.WrapHandler(s.bindingService.PrometheusHandler()), read)
 	s.echoWebServer.GET("/bindings", func(c echo.Context) error {
 		return c.JSONPretty(200, s.bindingService.GetStatus(), "\t")
-	})
+	}, read)
 	s.echoWebServer.GET("/bindings/stats", func(c echo.Context) error {
 		return c.JSONPretty(200, s.bindingService.Stats(), "\t")
-	})
+	}, read)
+	s.echoWebServer.GET("/bindings/:name/activity", s.bindingActivity, read)
+	s.echoWebServer.POST("/bindings/:name/replay", s.replayBinding, s.auth.authorize(config.ApiRoleRequest))
 	s.echoWebServer.POST("/bindings/request", func(c echo.Context) error {
 		req := &binding.Request{}
 		err := c.Bind(req)
@@ -48,11 +75,35 @@ func Start(ctx context.Context, port int, bs *binding.Service) (*Server, error)
 			}, "\t")
 		}
 		return c.JSONPretty(200, s.bindingService.SendRequest(c.Request().Context(), req), "\t")
-	})
+	}, s.auth.authorize(config.ApiRoleRequest))
+	s.echoWebServer.POST("/bindings", s.createBinding, admin)
+	s.echoWebServer.PUT("/bindings/:name", s.updateBinding, admin)
+	s.echoWebServer.DELETE("/bindings/:name", s.deleteBinding, admin)
+	s.echoWebServer.POST("/bindings/:name/pause", s.pauseBinding, admin)
+	s.echoWebServer.POST("/bindings/:name/resume", s.resumeBinding, admin)
+	s.echoWebServer.POST("/bindings/:name/retry", s.retryBinding, admin)
+	return s
+}
+
+func Start(ctx context.Context, cfg *config.Config, bs *binding.Service) (*Server, error) {
+	s := newServer(cfg, bs)
+	address := fmt.Sprintf("0.0.0.0:%d", cfg.ApiPort)
 	errCh := make(chan error, 1)
-	go func() {
-		errCh <- s.echoWebServer.Start(fmt.Sprintf("0.0.0.0:%d", port))
-	}()
+	if cfg.Api.TLSEnabled() {
+		tlsConfig, err := newTLSConfig(cfg.Api)
+		if err != nil {
+			return nil, err
+		}
+		s.echoWebServer.TLSServer.Addr = address
+		s.echoWebServer.TLSServer.TLSConfig = tlsConfig
+		go func() {
+			errCh <- s.echoWebServer.StartServer(s.echoWebServer.TLSServer)
+		}()
+	} else {
+		go func() {
+			errCh <- s.echoWebServer.Start(address)
+		}()
+	}
 
 	select {
 	case err := <-errCh:
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kubemq-io/kubemq-targets/binding"
+	"github.com/kubemq-io/kubemq-targets/config"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_CORS(t *testing.T) {
+	bs, err := binding.New()
+	require.NoError(t, err)
+	tests := []struct {
+		name            string
+		origins         []string
+		origin          string
+		wantAllowOrigin string
+	}{
+		{
+			name:            "default - any origin",
+			origins:         nil,
+			origin:          "http://any.example.com",
+			wantAllowOrigin: "*",
+		},
+		{
+			name:            "allowed origin",
+			origins:         []string{"http://ui.example.com"},
+			origin:          "http://ui.example.com",
+			wantAllowOrigin: "http://ui.example.com",
+		},
+		{
+			name:            "not allowed origin",
+			origins:         []string{"http://ui.example.com"},
+			origin:          "http://other.example.com",
+			wantAllowOrigin: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newServer(&config.Config{Api: config.ApiConfig{CorsOrigins: tt.origins}}, bs)
+			r := httptest.NewRequest(http.MethodOptions, "/bindings", nil)
+			r.Header.Set(echo.HeaderOrigin, tt.origin)
+			r.Header.Set(echo.HeaderAccessControlRequestMethod, http.MethodGet)
+			w := httptest.NewRecorder()
+			s.echoWebServer.ServeHTTP(w, r)
+			require.Equal(t, tt.wantAllowOrigin, w.Header().Get(echo.HeaderAccessControlAllowOrigin))
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package binding
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/kubemq-io/kubemq-targets/types"
+)
+
+const (
+	defaultInitRetryInitialMilliseconds = 1000
+	defaultInitRetryMaxMilliseconds     = 60000
+	defaultInitRetryMultiplier          = 2
+	defaultInitRetryJitterPercent       = 20
+)
+
+// initBackoff sets the delays between the initialization attempts of a binding
+type initBackoff struct {
+	initial     time.Duration
+	max         time.Duration
+	multiplier  float64
+	jitter      float64
+	maxAttempts int
+}
+
+func newInitBackoff(meta types.Metadata) (*initBackoff, error) {
+	initial, err := meta.ParseIntWithRange("init_retry_initial_milliseconds", defaultInitRetryInitialMilliseconds, 1, math.MaxInt32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid init retry initial milliseconds value, %w", err)
+	}
+	defaultMax := defaultInitRetryMaxMilliseconds
+	if initial > defaultMax {
+		defaultMax = initial
+	}
+	max, err := meta.ParseIntWithRange("init_retry_max_milliseconds", defaultMax, initial, math.MaxInt32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid init retry max milliseconds value, %w", err)
+	}
+	multiplier, err := meta.ParseFloatWithRange("init_retry_multiplier", defaultInitRetryMultiplier, 1, 100)
+	if err != nil {
+		return nil, fmt.Errorf("invalid init retry multiplier value, %w", err)
+	}
+	jitter, err := meta.ParseIntWithRange("init_retry_jitter_percent", defaultInitRetryJitterPercent, 0, 100)
+	if err != nil {
+		return nil, fmt.Errorf("invalid init retry jitter percent value, %w", err)
+	}
+	maxAttempts, err := meta.ParseIntWithRange("init_retry_max_attempts", 0, 0, math.MaxInt32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid init retry max attempts value, %w", err)
+	}
+	return &initBackoff{
+		initial:     time.Duration(initial) * time.Millisecond,
+		max:         time.Duration(max) * time.Millisecond,
+		multiplier:  multiplier,
+		jitter:      float64(jitter) / 100,
+		maxAttempts: maxAttempts,
+	}, nil
+}
+
+// delay returns the delay after the failed attempt, the first attempt is 1, it never exceeds the max delay
+func (b *initBackoff) delay(attempt int) time.Duration {
+	delay := float64(b.initial) * math.Pow(b.multiplier, float64(attempt-1))
+	if delay > float64(b.max) {
+		delay = float64(b.max)
+	}
+	if b.jitter > 0 {
+		delay += delay * b.jitter * (2*rand.Float64() - 1)
+	}
+	if delay > float64(b.max) {
+		delay = float64(b.max)
+	}
+	return time.Duration(delay)
+}
+
+// exhausted returns true when no attempt is left after the failed attempt
+func (b *initBackoff) exhausted(attempt int) bool {
+	return b.maxAttempts > 0 && attempt >= b.maxAttempts
+}
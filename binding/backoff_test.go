@@ -0,0 +1,181 @@
+package binding
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kubemq-io/kubemq-targets/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInitBackoff_New(t *testing.T) {
+	tests := []struct {
+		name    string
+		meta    types.Metadata
+		want    *initBackoff
+		wantErr bool
+	}{
+		{
+			name: "default values",
+			meta: types.Metadata{},
+			want: &initBackoff{
+				initial:    time.Second,
+				max:        time.Minute,
+				multiplier: 2,
+				jitter:     0.2,
+			},
+		},
+		{
+			name: "initial above default max",
+			meta: types.Metadata{
+				"init_retry_initial_milliseconds": "120000",
+			},
+			want: &initBackoff{
+				initial:    2 * time.Minute,
+				max:        2 * time.Minute,
+				multiplier: 2,
+				jitter:     0.2,
+			},
+		},
+		{
+			name: "all values",
+			meta: types.Metadata{
+				"init_retry_initial_milliseconds": "500",
+				"init_retry_max_milliseconds":     "10000",
+				"init_retry_multiplier":           "1.5",
+				"init_retry_jitter_percent":       "0",
+				"init_retry_max_attempts":         "5",
+			},
+			want: &initBackoff{
+				initial:     500 * time.Millisecond,
+				max:         10 * time.Second,
+				multiplier:  1.5,
+				maxAttempts: 5,
+			},
+		},
+		{
+			name: "invalid max lower than initial",
+			meta: types.Metadata{
+				"init_retry_initial_milliseconds": "5000",
+				"init_retry_max_milliseconds":     "1000",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid multiplier",
+			meta: types.Metadata{
+				"init_retry_multiplier": "0.5",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid multiplier format",
+			meta: types.Metadata{
+				"init_retry_multiplier": "bad",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid jitter",
+			meta: types.Metadata{
+				"init_retry_jitter_percent": "101",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid max attempts",
+			meta: types.Metadata{
+				"init_retry_max_attempts": "-1",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newInitBackoff(tt.meta)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestInitBackoff_Delay(t *testing.T) {
+	b := &initBackoff{
+		initial:    time.Second,
+		max:        10 * time.Second,
+		multiplier: 2,
+	}
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 1, want: time.Second},
+		{attempt: 2, want: 2 * time.Second},
+		{attempt: 3, want: 4 * time.Second},
+		{attempt: 4, want: 8 * time.Second},
+		{attempt: 5, want: 10 * time.Second},
+		{attempt: 2000, want: 10 * time.Second},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.want, b.delay(tt.attempt), "attempt %d", tt.attempt)
+	}
+}
+
+func TestInitBackoff_Jitter(t *testing.T) {
+	b := &initBackoff{
+		initial:    time.Second,
+		max:        4 * time.Second,
+		multiplier: 2,
+		jitter:     0.2,
+	}
+	for attempt := 1; attempt <= 5; attempt++ {
+		base := b.initial << (attempt - 1)
+		if base > b.max {
+			base = b.max
+		}
+		for i := 0; i < 100; i++ {
+			delay := b.delay(attempt)
+			require.GreaterOrEqual(t, delay, base*8/10)
+			require.LessOrEqual(t, delay, base*12/10)
+			require.LessOrEqual(t, delay, b.max)
+		}
+	}
+}
+
+func TestInitBackoff_MaxWithJitter(t *testing.T) {
+	b := &initBackoff{
+		initial:    time.Second,
+		max:        time.Second,
+		multiplier: 2,
+		jitter:     1,
+	}
+	for attempt := 1; attempt <= 5; attempt++ {
+		for i := 0; i < 100; i++ {
+			require.LessOrEqual(t, b.delay(attempt), b.max)
+		}
+	}
+}
+
+func TestInitBackoff_Exhausted(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxAttempts int
+		attempt     int
+		want        bool
+	}{
+		{name: "unlimited", maxAttempts: 0, attempt: 1000, want: false},
+		{name: "attempts left", maxAttempts: 3, attempt: 2, want: false},
+		{name: "last attempt", maxAttempts: 3, attempt: 3, want: true},
+		{name: "single attempt", maxAttempts: 1, attempt: 1, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &initBackoff{maxAttempts: tt.maxAttempts}
+			require.Equal(t, tt.want, b.exhausted(tt.attempt))
+		})
+	}
+}
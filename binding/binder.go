@@ -3,28 +3,56 @@ package binding
 import (
 	"context"
 	"fmt"
+	"math"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/kubemq-io/kubemq-targets/config"
 	"github.com/kubemq-io/kubemq-targets/middleware"
+	"github.com/kubemq-io/kubemq-targets/pkg/journal"
 	"github.com/kubemq-io/kubemq-targets/pkg/logger"
 	"github.com/kubemq-io/kubemq-targets/pkg/metrics"
+	"github.com/kubemq-io/kubemq-targets/pkg/redact"
 	"github.com/kubemq-io/kubemq-targets/sources"
 	"github.com/kubemq-io/kubemq-targets/targets"
+	"github.com/kubemq-io/kubemq-targets/types"
+)
+
+const (
+	defaultDrainTimeoutMilliseconds = 30000
+	drainPollInterval               = 100 * time.Millisecond
 )
 
 type Binder struct {
-	name   string
-	log    *logger.Logger
-	source sources.Source
-	target targets.Target
-	md     middleware.Middleware
+	inFlight     int64
+	lastSuccess  int64
+	lastFailure  int64
+	lastError    atomic.Value
+	draining     int32
+	drainTimeout time.Duration
+	name         string
+	log          *logger.Logger
+	source       sources.Source
+	targets      []targets.Target
+	target       middleware.Middleware
+	md           middleware.Middleware
+	dl           *middleware.DeadLetterMiddleware
+	cb           *middleware.CircuitBreakerMiddleware
+	journal      *middleware.JournalMiddleware
+	logMd        *middleware.LogMiddleware
+	health       *healthChecker
+	batches      []*middleware.BatchMiddleware
+	cancel       context.CancelFunc
+	// resolved is the binding config with its secret references resolved, used to detect rotated secrets
+	resolved config.BindingConfig
 }
 
 func NewBinder() *Binder {
 	return &Binder{}
 }
 
-func (b *Binder) buildMiddleware(cfg config.BindingConfig, exporter *metrics.Exporter, log *middleware.LogMiddleware) (middleware.Middleware, error) {
+func (b *Binder) buildMiddleware(ctx context.Context, cfg config.BindingConfig, exporter *metrics.Exporter, log *middleware.LogMiddleware) (middleware.Middleware, error) {
 	retry, err := middleware.NewRetryMiddleware(cfg.Properties, b.log)
 	if err != nil {
 		return nil, err
@@ -41,10 +69,175 @@ func (b *Binder) buildMiddleware(cfg config.BindingConfig, exporter *metrics.Exp
 	if err != nil {
 		return nil, err
 	}
-	md := middleware.Chain(b.target, middleware.RateLimiter(rateLimiter), middleware.Retry(retry), middleware.Metric(met), middleware.Log(log), middleware.Metadata(meta))
+	transform, err := middleware.NewTransformMiddleware(cfg.Properties)
+	if err != nil {
+		return nil, err
+	}
+	timeout, err := middleware.NewTimeoutMiddleware(cfg.Properties)
+	if err != nil {
+		return nil, err
+	}
+	b.cb, err = middleware.NewCircuitBreakerMiddleware(cfg, exporter)
+	if err != nil {
+		return nil, err
+	}
+	b.dl, err = middleware.NewDeadLetterMiddleware(ctx, cfg, b.log)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := middleware.NewFilterMiddleware(cfg.Properties)
+	if err != nil {
+		return nil, err
+	}
+	b.journal, err = middleware.NewJournalMiddleware(cfg, b.logMd)
+	if err != nil {
+		return nil, err
+	}
+	met.SetMethods(targetMethods(b.targets...))
+	retry.SetMetrics(met)
+	rateLimiter.SetMetrics(met)
+	tr := middleware.NewTracingMiddleware(cfg)
+	target := b.target
+	if len(cfg.Targets) == 0 {
+		// routed targets are measured and traced one by one in buildTargetMiddleware
+		target = middleware.Chain(target, middleware.MetricTarget(met), middleware.TraceTarget(tr))
+	}
+	md := middleware.Chain(target,
+		middleware.Traced(tr, "timeout", middleware.Timeout(timeout)),
+		middleware.Traced(tr, "rate-limiter", middleware.RateLimiter(rateLimiter)),
+		middleware.Traced(tr, "circuit-breaker", middleware.CircuitBreaker(b.cb)),
+		middleware.Traced(tr, "retry", middleware.Retry(retry)),
+		middleware.Traced(tr, "dead-letter", middleware.DeadLetter(b.dl)),
+		middleware.Traced(tr, "metric", middleware.Metric(met)),
+		middleware.Traced(tr, "log", middleware.Log(log)),
+		middleware.Traced(tr, "transform", middleware.Transform(transform)),
+		middleware.Traced(tr, "filter", middleware.Filter(filter)),
+		middleware.Traced(tr, "metadata", middleware.Metadata(meta)),
+		middleware.Traced(tr, "journal", middleware.Journal(b.journal)))
 	return md, nil
 }
 
+func (b *Binder) buildBatchMiddleware(meta types.Metadata, target targets.Target) (middleware.Middleware, error) {
+	batch, err := middleware.NewBatchMiddleware(meta)
+	if err != nil {
+		return nil, err
+	}
+	if !batch.Enabled() {
+		return target, nil
+	}
+	batchTarget, ok := target.(targets.BatchTarget)
+	if !ok {
+		return nil, fmt.Errorf("target kind %s does not support batching", target.Connector().Kind)
+	}
+	batch.Start(batchTarget.DoBatch)
+	b.batches = append(b.batches, batch)
+	return batch, nil
+}
+
+func (b *Binder) buildTargetMiddleware(cfg config.BindingConfig, targetCfg config.TargetConfig, target targets.Target, exporter *metrics.Exporter) (middleware.Middleware, error) {
+	targetBindingCfg := config.BindingConfig{
+		Name:       cfg.Name,
+		Source:     cfg.Source,
+		Target:     targetCfg.Spec(),
+		Properties: targetCfg.Middleware,
+	}
+	met, err := middleware.NewMetricsMiddleware(targetBindingCfg, exporter)
+	if err != nil {
+		return nil, err
+	}
+	met.SetMethods(targetMethods(target))
+	tr := middleware.NewTracingMiddleware(targetBindingCfg)
+	if len(targetCfg.Middleware) == 0 {
+		return middleware.Chain(target, middleware.MetricTarget(met), middleware.TraceTarget(tr)), nil
+	}
+	batch, err := b.buildBatchMiddleware(targetCfg.Middleware, target)
+	if err != nil {
+		return nil, err
+	}
+	retry, err := middleware.NewRetryMiddleware(targetCfg.Middleware, b.log)
+	if err != nil {
+		return nil, err
+	}
+	retry.SetMetrics(met)
+	rateLimiter, err := middleware.NewRateLimitMiddleware(targetCfg.Middleware)
+	if err != nil {
+		return nil, err
+	}
+	rateLimiter.SetMetrics(met)
+	timeout, err := middleware.NewTimeoutMiddleware(targetCfg.Middleware)
+	if err != nil {
+		return nil, err
+	}
+	cb, err := middleware.NewCircuitBreakerMiddleware(targetBindingCfg, exporter)
+	if err != nil {
+		return nil, err
+	}
+	meta, err := middleware.NewMetadataMiddleware(targetCfg.Middleware)
+	if err != nil {
+		return nil, err
+	}
+	return middleware.Chain(batch,
+		middleware.MetricTarget(met),
+		middleware.TraceTarget(tr),
+		middleware.Traced(tr, "timeout", middleware.Timeout(timeout)),
+		middleware.Traced(tr, "rate-limiter", middleware.RateLimiter(rateLimiter)),
+		middleware.Traced(tr, "circuit-breaker", middleware.CircuitBreaker(cb)),
+		middleware.Traced(tr, "retry", middleware.Retry(retry)),
+		middleware.Traced(tr, "metadata", middleware.Metadata(meta))), nil
+}
+
+// targetMethods returns the request methods declared by the targets connectors
+func targetMethods(list ...targets.Target) []string {
+	var methods []string
+	for _, target := range list {
+		connector := target.Connector()
+		if connector == nil {
+			continue
+		}
+		for _, meta := range connector.Metadata {
+			if meta.Name == "method" {
+				methods = append(methods, meta.Options...)
+			}
+		}
+	}
+	return methods
+}
+
+func (b *Binder) initTargets(ctx context.Context, cfg config.BindingConfig, exporter *metrics.Exporter) (middleware.Middleware, error) {
+	if len(cfg.Targets) == 0 {
+		target, err := targets.Init(ctx, cfg.Target, b.log)
+		if err != nil {
+			return nil, err
+		}
+		b.targets = append(b.targets, target)
+		name := cfg.Target.Name
+		if name == "" {
+			name = cfg.Target.Kind
+		}
+		b.health.add(name, target)
+		return b.buildBatchMiddleware(cfg.Properties, target)
+	}
+	var routes []*middleware.Route
+	for _, targetCfg := range cfg.Targets {
+		target, err := targets.Init(ctx, targetCfg.Spec(), b.log)
+		if err != nil {
+			return nil, fmt.Errorf("target %s, %w", targetCfg.Name, err)
+		}
+		b.targets = append(b.targets, target)
+		b.health.add(targetCfg.Name, target)
+		md, err := b.buildTargetMiddleware(cfg, targetCfg, target, exporter)
+		if err != nil {
+			return nil, fmt.Errorf("target %s middlewares, %w", targetCfg.Name, err)
+		}
+		route, err := middleware.NewRoute(targetCfg.Name, targetCfg.Filter, md)
+		if err != nil {
+			return nil, err
+		}
+		routes = append(routes, route)
+	}
+	return middleware.NewRouterMiddleware(routes, cfg.Strategy)
+}
+
 func (b *Binder) Init(ctx context.Context, cfg config.BindingConfig, exporter *metrics.Exporter) error {
 	b.name = cfg.Name
 	log, err := middleware.NewLogMiddleware(cfg.Name, cfg.Properties)
@@ -52,20 +245,45 @@ func (b *Binder) Init(ctx context.Context, cfg config.BindingConfig, exporter *m
 		return err
 	}
 	b.log = log.Logger
+	b.logMd = log
+	drainTimeout, err := cfg.Properties.ParseIntWithRange("drain_timeout_milliseconds", defaultDrainTimeoutMilliseconds, 0, math.MaxInt32)
+	if err != nil {
+		return fmt.Errorf("invalid drain timeout milliseconds value on binding %s, %w", b.name, err)
+	}
+	b.drainTimeout = time.Duration(drainTimeout) * time.Millisecond
+	b.health, err = newHealthChecker(cfg)
+	if err != nil {
+		return fmt.Errorf("binding %s, %w", b.name, err)
+	}
 
-	b.target, err = targets.Init(ctx, cfg.Target, b.log)
+	b.target, err = b.initTargets(ctx, cfg, exporter)
 	if err != nil {
 		return fmt.Errorf("error loading target conntector on binding %s, %w", b.name, err)
 	}
 	b.log.Infof("binding: %s target: initialized successfully", b.name)
-	b.md, err = b.buildMiddleware(cfg, exporter, log)
+	md, err := b.buildMiddleware(ctx, cfg, exporter, log)
 	if err != nil {
 		return fmt.Errorf("error loading middlewares on binding %s, %w", b.name, err)
 	}
-	b.source, err = sources.Init(ctx, cfg.Source, b.log)
+	b.md = middleware.Chain(md, b.track)
+	sourceCfg := cfg.Source
+	if sourceCfg.Name == "" {
+		sourceCfg.Name = cfg.Name
+	}
+	b.source, err = sources.Init(ctx, sourceCfg, b.log)
 	if err != nil {
 		return fmt.Errorf("error loading source conntector on binding %s, %w", b.name, err)
 	}
+	if reporter, ok := b.source.(sources.QueueDepthReporter); ok && exporter != nil {
+		report := &metrics.Report{
+			Binding:    cfg.Name,
+			SourceKind: cfg.Source.Kind,
+			TargetKind: cfg.TargetKind(),
+		}
+		reporter.SetQueueDepthReporter(func(depth int) {
+			exporter.ReportSourceQueueDepth(report, float64(depth))
+		})
+	}
 	b.log.Infof("binding: %s source initialized successfully", b.name)
 	b.log.Infof("binding: %s, initialized successfully", b.name)
 	return nil
@@ -78,25 +296,159 @@ func (b *Binder) Start(ctx context.Context) error {
 	if b.source == nil {
 		return fmt.Errorf("error starting binding connector %s,no valid initialzed source found", b.name)
 	}
-	err := b.source.Start(ctx, b.md)
+	err := b.source.Start(ctx, middleware.Chain(b.md, middleware.StripRouterMetadata))
 	if err != nil {
 		return err
 	}
+	b.health.start(ctx)
 	b.log.Infof("binding: %s, started successfully", b.name)
 	return nil
 }
 
-func (b *Binder) Stop() error {
-	err := b.source.Stop()
-	if err != nil {
-		return err
+// track counts the requests in flight through the binding middlewares
+func (b *Binder) track(next middleware.Middleware) middleware.Middleware {
+	return middleware.DoFunc(func(ctx context.Context, request *types.Request) (*types.Response, error) {
+		atomic.AddInt64(&b.inFlight, 1)
+		defer atomic.AddInt64(&b.inFlight, -1)
+		resp, err := next.Do(ctx, request)
+		switch {
+		case err != nil:
+			b.lastError.Store(err.Error())
+			atomic.StoreInt64(&b.lastFailure, time.Now().UnixNano())
+		case resp != nil && resp.IsError:
+			b.lastError.Store(resp.Error)
+			atomic.StoreInt64(&b.lastFailure, time.Now().UnixNano())
+		default:
+			atomic.StoreInt64(&b.lastSuccess, time.Now().UnixNano())
+		}
+		return resp, err
+	})
+}
+
+// setRequestsStatus sets the last successful and failed requests of the binding in status
+func (b *Binder) setRequestsStatus(status *Status) {
+	if lastSuccess := atomic.LoadInt64(&b.lastSuccess); lastSuccess > 0 {
+		at := time.Unix(0, lastSuccess)
+		status.LastSuccessAt = &at
+	}
+	if lastFailure := atomic.LoadInt64(&b.lastFailure); lastFailure > 0 {
+		at := time.Unix(0, lastFailure)
+		status.LastFailureAt = &at
+		lastError, _ := b.lastError.Load().(string)
+		status.LastError = redact.Text(lastError)
 	}
+}
 
-	err = b.target.Stop()
-	if err != nil {
+// drain waits until no request is in flight or the deadline has passed, it returns the number of requests left in flight
+func (b *Binder) drain(deadline time.Time) int64 {
+	for {
+		inFlight := atomic.LoadInt64(&b.inFlight)
+		if inFlight == 0 || time.Now().After(deadline) {
+			return inFlight
+		}
+		time.Sleep(drainPollInterval)
+	}
+}
+
+// InFlight returns the number of requests in flight through the binding
+func (b *Binder) InFlight() int64 {
+	return atomic.LoadInt64(&b.inFlight)
+}
+
+// Draining reports whether the binding is stopping and waiting for its in-flight requests
+func (b *Binder) Draining() bool {
+	return atomic.LoadInt32(&b.draining) == 1
+}
+
+// Activity returns the journal activities matching the filter, with the binding log redaction rules applied
+func (b *Binder) Activity(filter journal.Filter) []*types.Activity {
+	var list []*types.Activity
+	for _, activity := range b.journal.List(filter) {
+		list = append(list, b.logMd.RedactActivity(activity))
+	}
+	return list
+}
+
+// Stop stops consuming new messages, waits up to the drain timeout for the in-flight requests,
+// flushes pending batches and then closes the targets
+func (b *Binder) Stop() error {
+	if b.cancel != nil {
+		defer b.cancel()
+	}
+	atomic.StoreInt32(&b.draining, 1)
+	deadline := time.Now().Add(b.drainTimeout)
+	for _, batch := range b.batches {
+		batch.Flush()
+	}
+	var errs []string
+	stopped := make(chan error, 1)
+	go func() {
+		stopped <- b.source.Stop()
+	}()
+	select {
+	case err := <-stopped:
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("source: %s", err.Error()))
+		}
+	case <-time.After(time.Until(deadline)):
+		b.log.Errorf("binding: %s, drain timeout, source did not stop", b.name)
+	}
+	if inFlight := b.drain(deadline); inFlight > 0 {
+		b.log.Errorf("binding: %s, drain timeout, %d requests still in flight", b.name, inFlight)
+	}
+	if b.cancel != nil {
+		b.cancel()
+	}
+	errs = append(errs, b.closeComponents()...)
+	if err := stopError(b.name, errs); err != nil {
 		return err
 	}
 	b.log.Infof("binding: %s, stopped successfully", b.name)
-
 	return nil
 }
+
+// teardown releases the components of a binder which failed to initialize or start
+func (b *Binder) teardown() error {
+	if b.cancel != nil {
+		defer b.cancel()
+	}
+	var errs []string
+	if b.source != nil {
+		if err := b.source.Stop(); err != nil {
+			errs = append(errs, fmt.Sprintf("source: %s", err.Error()))
+		}
+	}
+	errs = append(errs, b.closeComponents()...)
+	return stopError(b.name, errs)
+}
+
+// closeComponents closes the batches, targets, dead letter client and journal of the binder and returns their errors
+func (b *Binder) closeComponents() []string {
+	var errs []string
+	for _, batch := range b.batches {
+		batch.Close()
+	}
+	for _, target := range b.targets {
+		if err := target.Stop(); err != nil {
+			errs = append(errs, fmt.Sprintf("target %s: %s", target.Connector().Kind, err.Error()))
+		}
+	}
+	if b.dl != nil {
+		if err := b.dl.Close(); err != nil {
+			errs = append(errs, fmt.Sprintf("dead letter: %s", err.Error()))
+		}
+	}
+	if b.journal != nil {
+		if err := b.journal.Close(); err != nil {
+			errs = append(errs, fmt.Sprintf("journal: %s", err.Error()))
+		}
+	}
+	return errs
+}
+
+func stopError(name string, errs []string) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("error stopping binding %s, %s", name, strings.Join(errs, ", "))
+}
@@ -0,0 +1,158 @@
+package binding
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/kubemq-io/kubemq-targets/config"
+	"github.com/kubemq-io/kubemq-targets/targets"
+)
+
+const (
+	defaultHealthCheckIntervalSeconds     = 30
+	defaultHealthCheckTimeoutMilliseconds = 5000
+)
+
+type TargetHealth struct {
+	Target  string `json:"target"`
+	Healthy bool   `json:"healthy"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Health is the result of the last probe of the binding targets implementing targets.HealthChecker
+type Health struct {
+	Healthy   bool            `json:"healthy"`
+	CheckedAt *time.Time      `json:"checked_at,omitempty"`
+	Targets   []*TargetHealth `json:"targets,omitempty"`
+}
+
+type healthChecker struct {
+	mu       sync.RWMutex
+	interval time.Duration
+	timeout  time.Duration
+	names    []string
+	checkers []targets.HealthChecker
+	health   *Health
+}
+
+func newHealthChecker(cfg config.BindingConfig) (*healthChecker, error) {
+	interval, err := cfg.Properties.ParseIntWithRange("health_check_interval_seconds", defaultHealthCheckIntervalSeconds, 0, math.MaxInt32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid health check interval seconds value, %w", err)
+	}
+	timeout, err := cfg.Properties.ParseIntWithRange("health_check_timeout_milliseconds", defaultHealthCheckTimeoutMilliseconds, 1, math.MaxInt32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid health check timeout milliseconds value, %w", err)
+	}
+	return &healthChecker{
+		interval: time.Duration(interval) * time.Second,
+		timeout:  time.Duration(timeout) * time.Millisecond,
+		health:   &Health{Healthy: true},
+	}, nil
+}
+
+// add registers the target to probe if it implements targets.HealthChecker
+func (h *healthChecker) add(name string, target targets.Target) {
+	if checker, ok := target.(targets.HealthChecker); ok {
+		h.names = append(h.names, name)
+		h.checkers = append(h.checkers, checker)
+	}
+}
+
+func (h *healthChecker) check(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+	now := time.Now()
+	health := &Health{
+		Healthy:   true,
+		CheckedAt: &now,
+	}
+	for i, checker := range h.checkers {
+		result := &TargetHealth{
+			Target:  h.names[i],
+			Healthy: true,
+		}
+		if err := checker.Check(ctx); err != nil {
+			result.Healthy = false
+			result.Error = err.Error()
+			health.Healthy = false
+		}
+		health.Targets = append(health.Targets, result)
+	}
+	h.mu.Lock()
+	h.health = health
+	h.mu.Unlock()
+}
+
+// start probes the targets now and then every interval until ctx is done
+func (h *healthChecker) start(ctx context.Context) {
+	if len(h.checkers) == 0 || h.interval == 0 {
+		return
+	}
+	h.check(ctx)
+	go func() {
+		for {
+			select {
+			case <-time.After(h.interval):
+				h.check(ctx)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// get returns a copy of the last probe result
+func (h *healthChecker) get() *Health {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	health := *h.health
+	return &health
+}
+
+// withoutErrors returns a copy of the health without the targets error texts
+func (h *Health) withoutErrors() *Health {
+	if h == nil {
+		return nil
+	}
+	health := &Health{
+		Healthy:   h.Healthy,
+		CheckedAt: h.CheckedAt,
+	}
+	for _, target := range h.Targets {
+		health.Targets = append(health.Targets, &TargetHealth{
+			Target:  target.Target,
+			Healthy: target.Healthy,
+		})
+	}
+	return health
+}
+
+// Readiness is the readiness of one binding
+type Readiness struct {
+	Binding string  `json:"binding"`
+	Ready   bool    `json:"ready"`
+	State   string  `json:"state"`
+	Health  *Health `json:"health,omitempty"`
+}
+
+// Ready returns true when every binding which is not paused is running and not degraded, with the readiness of each binding,
+// the readiness is served without authentication so it holds no error texts, these are in the binding status
+func (s *Service) Ready() (bool, []*Readiness) {
+	ready := true
+	list := []*Readiness{}
+	for _, status := range s.GetStatus() {
+		readiness := &Readiness{
+			Binding: status.Binding,
+			State:   status.State,
+			Health:  status.Health.withoutErrors(),
+		}
+		readiness.Ready = status.State == StateRunning || status.State == StatePaused
+		ready = ready && readiness.Ready
+		list = append(list, readiness)
+	}
+	return ready, list
+}
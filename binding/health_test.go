@@ -0,0 +1,199 @@
+package binding
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kubemq-io/kubemq-targets/config"
+	"github.com/kubemq-io/kubemq-targets/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthChecker_New(t *testing.T) {
+	tests := []struct {
+		name         string
+		meta         types.Metadata
+		wantInterval time.Duration
+		wantTimeout  time.Duration
+		wantErr      bool
+	}{
+		{
+			name:         "default values",
+			meta:         types.Metadata{},
+			wantInterval: 30 * time.Second,
+			wantTimeout:  5 * time.Second,
+		},
+		{
+			name: "custom values",
+			meta: types.Metadata{
+				"health_check_interval_seconds":     "10",
+				"health_check_timeout_milliseconds": "100",
+			},
+			wantInterval: 10 * time.Second,
+			wantTimeout:  100 * time.Millisecond,
+		},
+		{
+			name: "disabled",
+			meta: types.Metadata{
+				"health_check_interval_seconds": "0",
+			},
+			wantInterval: 0,
+			wantTimeout:  5 * time.Second,
+		},
+		{
+			name: "invalid interval",
+			meta: types.Metadata{
+				"health_check_interval_seconds": "-1",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid timeout",
+			meta: types.Metadata{
+				"health_check_timeout_milliseconds": "0",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, err := newHealthChecker(config.BindingConfig{Properties: tt.meta})
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantInterval, h.interval)
+			require.Equal(t, tt.wantTimeout, h.timeout)
+			require.True(t, h.get().Healthy)
+		})
+	}
+}
+
+func TestHealthChecker_Check(t *testing.T) {
+	tests := []struct {
+		name        string
+		targets     map[string]*mockTarget
+		wantHealthy bool
+		wantErrors  map[string]string
+	}{
+		{
+			name:        "no targets",
+			wantHealthy: true,
+		},
+		{
+			name: "healthy targets",
+			targets: map[string]*mockTarget{
+				"a": {},
+				"b": {},
+			},
+			wantHealthy: true,
+		},
+		{
+			name: "unhealthy target",
+			targets: map[string]*mockTarget{
+				"a": {},
+				"b": {checkErr: fmt.Errorf("target is down")},
+			},
+			wantHealthy: false,
+			wantErrors: map[string]string{
+				"b": "target is down",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, err := newHealthChecker(config.BindingConfig{Properties: types.Metadata{}})
+			require.NoError(t, err)
+			for name, target := range tt.targets {
+				h.add(name, target)
+			}
+			h.check(context.Background())
+			health := h.get()
+			require.Equal(t, tt.wantHealthy, health.Healthy)
+			require.NotNil(t, health.CheckedAt)
+			require.Len(t, health.Targets, len(tt.targets))
+			for _, target := range health.Targets {
+				require.Equal(t, tt.wantErrors[target.Target], target.Error)
+				require.Equal(t, tt.wantErrors[target.Target] == "", target.Healthy)
+			}
+		})
+	}
+}
+
+func TestService_Ready(t *testing.T) {
+	tests := []struct {
+		name         string
+		bindings     map[string]*Status
+		wantReady    bool
+		wantNotReady map[string]bool
+	}{
+		{
+			name:      "no bindings",
+			wantReady: true,
+		},
+		{
+			name: "running and paused bindings",
+			bindings: map[string]*Status{
+				"running": {State: StateRunning},
+				"paused":  {State: StatePaused},
+			},
+			wantReady: true,
+		},
+		{
+			name: "initializing binding",
+			bindings: map[string]*Status{
+				"running":      {State: StateRunning},
+				"initializing": {State: StateInitializing, LastError: "init error"},
+			},
+			wantReady:    false,
+			wantNotReady: map[string]bool{"initializing": true},
+		},
+		{
+			name: "failed binding",
+			bindings: map[string]*Status{
+				"failed": {State: StateFailed, LastError: "init error"},
+			},
+			wantReady:    false,
+			wantNotReady: map[string]bool{"failed": true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(t)
+			for name, status := range tt.bindings {
+				addTestBinding(s, config.BindingConfig{Name: name}, nil)
+				status.Binding = name
+				s.bindingStatus.Store(name, status)
+			}
+			ready, list := s.Ready()
+			require.Equal(t, tt.wantReady, ready)
+			require.Len(t, list, len(tt.bindings))
+			for _, readiness := range list {
+				require.Equal(t, !tt.wantNotReady[readiness.Binding], readiness.Ready)
+			}
+		})
+	}
+}
+
+func TestService_Ready_Degraded(t *testing.T) {
+	s := newTestService(t)
+	cfg := newTestBindingConfig("degraded", false)
+	binder := newTestBinder(t, cfg, &mockSource{}, &mockTarget{checkErr: fmt.Errorf("target is down")})
+	addTestBinding(s, cfg, binder)
+	ready, _ := s.Ready()
+	require.True(t, ready)
+	binder.health.check(context.Background())
+	ready, list := s.Ready()
+	require.False(t, ready)
+	require.Len(t, list, 1)
+	require.Equal(t, StateDegraded, list[0].State)
+	require.False(t, list[0].Health.Healthy)
+	require.Len(t, list[0].Health.Targets, 1)
+	require.False(t, list[0].Health.Targets[0].Healthy)
+	require.Empty(t, list[0].Health.Targets[0].Error)
+	status := statusOf(s, cfg.Name)
+	require.Equal(t, "target is down", status.Health.Targets[0].Error)
+}
@@ -0,0 +1,78 @@
+package binding
+
+import (
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/kubemq-hub/builder/connector/common"
+	"github.com/kubemq-io/kubemq-targets/config"
+	"github.com/kubemq-io/kubemq-targets/pkg/redact"
+	"github.com/kubemq-io/kubemq-targets/sources"
+	"github.com/kubemq-io/kubemq-targets/targets"
+)
+
+var (
+	secretPropertiesOnce sync.Once
+	// secretProperties holds the secret properties names of each connector kind
+	secretProperties map[string]map[string]bool
+)
+
+func loadSecretProperties() {
+	secretProperties = map[string]map[string]bool{}
+	for _, list := range []common.Connectors{sources.Connectors(), targets.Connectors()} {
+		for _, connector := range list {
+			names := map[string]bool{}
+			addSecretProperties(names, connector.Properties)
+			secretProperties[connector.Kind] = names
+		}
+	}
+}
+
+func addSecretProperties(names map[string]bool, properties []*common.Property) {
+	for _, property := range properties {
+		if redact.IsSecretName(property.Name) || isSecretTitle(property.Title) {
+			names[property.Name] = true
+		}
+		for _, conditional := range property.Conditional {
+			addSecretProperties(names, conditional)
+		}
+	}
+}
+
+// isSecretTitle returns true for properties titled as credentials under a generic name, as a redis url connection string
+func isSecretTitle(title string) bool {
+	title = strings.ToLower(title)
+	return strings.Contains(title, "connection string") || strings.Contains(title, "password") || strings.Contains(title, "secret")
+}
+
+// redactProperties masks the secret properties of the connector kind, properties not in the connector schema are masked by name
+func redactProperties(kind string, properties map[string]string) map[string]string {
+	secretPropertiesOnce.Do(loadSecretProperties)
+	names, ok := secretProperties[kind]
+	return redact.Properties(properties, func(key string) bool {
+		if ok && names[key] {
+			return true
+		}
+		return redact.IsSecretName(key)
+	})
+}
+
+// redactError returns err with the values of the binding secret properties masked, as connection strings in connectors
+// initialization errors, the status masks the url credentials of any error
+func redactError(err error, cfg config.BindingConfig) error {
+	message := err.Error()
+	specs := []config.Spec{cfg.Source, cfg.Target}
+	for _, target := range cfg.Targets {
+		specs = append(specs, target.Spec())
+	}
+	for _, spec := range specs {
+		redacted := redactProperties(spec.Kind, spec.Properties)
+		for key, value := range spec.Properties {
+			if value != "" && redacted[key] == redact.Mask {
+				message = strings.ReplaceAll(message, value, redact.Mask)
+			}
+		}
+	}
+	return errors.New(message)
+}
@@ -0,0 +1,233 @@
+package binding
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kubemq-io/kubemq-targets/middleware"
+	"github.com/kubemq-io/kubemq-targets/pkg/journal"
+	"github.com/kubemq-io/kubemq-targets/pkg/ratelimit"
+	"github.com/kubemq-io/kubemq-targets/types"
+)
+
+const (
+	ReplaySourceJournal    = "journal"
+	ReplaySourceDeadLetter = "dead-letter"
+
+	ReplayStatusAll = "all"
+
+	ReplayOutcomeSuccess = "success"
+	ReplayOutcomeError   = "error"
+	ReplayOutcomeDryRun  = "dry-run"
+
+	defaultReplayLimit = 100
+	maxReplayLimit     = 10000
+)
+
+// ReplayRequest selects the stored requests of a binding to execute again
+type ReplayRequest struct {
+	Source        string    `json:"source"`
+	Status        string    `json:"status"`
+	Ids           []int64   `json:"ids"`
+	Since         time.Time `json:"since"`
+	Until         time.Time `json:"until"`
+	Limit         int       `json:"limit"`
+	DryRun        bool      `json:"dry_run"`
+	RatePerSecond int       `json:"rate_per_second"`
+}
+
+func (r *ReplayRequest) Validate() error {
+	switch r.Source {
+	case "":
+		r.Source = ReplaySourceJournal
+	case ReplaySourceJournal, ReplaySourceDeadLetter:
+	default:
+		return fmt.Errorf("invalid replay source %s", r.Source)
+	}
+	switch r.Status {
+	case "":
+		r.Status = types.ActivityStatusError
+	case ReplayStatusAll, types.ActivityStatusSuccess, types.ActivityStatusError:
+	default:
+		return fmt.Errorf("invalid replay status %s", r.Status)
+	}
+	if r.Limit == 0 {
+		r.Limit = defaultReplayLimit
+	}
+	if r.Limit < 0 || r.Limit > maxReplayLimit {
+		return fmt.Errorf("invalid replay limit %d, must be between 1 and %d", r.Limit, maxReplayLimit)
+	}
+	if r.RatePerSecond < 0 {
+		return fmt.Errorf("invalid replay rate per second %d", r.RatePerSecond)
+	}
+	return nil
+}
+
+func (r *ReplayRequest) inRange(t time.Time) bool {
+	if !r.Since.IsZero() && t.Before(r.Since) {
+		return false
+	}
+	if !r.Until.IsZero() && t.After(r.Until) {
+		return false
+	}
+	return true
+}
+
+func (r *ReplayRequest) hasId(id int64) bool {
+	if len(r.Ids) == 0 {
+		return true
+	}
+	for _, item := range r.Ids {
+		if item == id {
+			return true
+		}
+	}
+	return false
+}
+
+// ReplayOutcome is the result of executing again a stored request
+type ReplayOutcome struct {
+	Id        int64     `json:"id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	Status    string    `json:"status"`
+	Response  *Response `json:"response,omitempty"`
+	Error     string    `json:"error,omitempty"`
+}
+
+type ReplayResult struct {
+	Binding   string           `json:"binding"`
+	Source    string           `json:"source"`
+	DryRun    bool             `json:"dry_run"`
+	Total     int              `json:"total"`
+	Succeeded int              `json:"succeeded"`
+	Failed    int              `json:"failed"`
+	Outcomes  []*ReplayOutcome `json:"outcomes"`
+}
+
+type replayItem struct {
+	id         int64
+	createdAt  time.Time
+	request    *types.Request
+	deadLetter *types.DeadLetter
+}
+
+// journalItems returns the journal requests selected by req, oldest first. The activities of replays and the ones
+// already replayed successfully are skipped, so a request is not executed again by each replay, and the redacted
+// activities loaded from the journal file are skipped as their request is not the one received
+func (b *Binder) journalItems(req *ReplayRequest) []*replayItem {
+	replayed := map[int64]bool{}
+	for _, activity := range b.journal.List(journal.Filter{Status: types.ActivityStatusSuccess}) {
+		if activity.ReplayOf != 0 {
+			replayed[activity.ReplayOf] = true
+		}
+	}
+	filter := journal.Filter{
+		Since: req.Since,
+		Until: req.Until,
+	}
+	if req.Status != ReplayStatusAll {
+		filter.Status = req.Status
+	}
+	var items []*replayItem
+	for _, activity := range b.journal.List(filter) {
+		if len(items) == req.Limit {
+			break
+		}
+		if activity.Request == nil || activity.Redacted || activity.ReplayOf != 0 || replayed[activity.Id] || !req.hasId(activity.Id) {
+			continue
+		}
+		items = append([]*replayItem{{
+			id:        activity.Id,
+			createdAt: activity.CreatedAt,
+			request:   activity.Request,
+		}}, items...)
+	}
+	return items
+}
+
+// deadLetterItems takes the binding dead letters selected by req from the dead letter queue
+func (b *Binder) deadLetterItems(ctx context.Context, req *ReplayRequest) ([]*replayItem, error) {
+	deadLetters, err := b.dl.Receive(ctx, req.Limit, req.DryRun, func(deadLetter *types.DeadLetter) bool {
+		return deadLetter.Binding == b.name && req.inRange(time.Unix(0, deadLetter.Timestamp))
+	})
+	var items []*replayItem
+	for _, deadLetter := range deadLetters {
+		items = append(items, &replayItem{
+			createdAt:  time.Unix(0, deadLetter.Timestamp),
+			request:    deadLetter.Request,
+			deadLetter: deadLetter,
+		})
+	}
+	return items, err
+}
+
+// Replay executes again the stored requests selected by req through the binding middlewares and target
+func (b *Binder) Replay(ctx context.Context, req *ReplayRequest) (*ReplayResult, error) {
+	var items []*replayItem
+	switch req.Source {
+	case ReplaySourceDeadLetter:
+		var err error
+		items, err = b.deadLetterItems(ctx, req)
+		if err != nil && len(items) == 0 {
+			return nil, err
+		}
+		if err != nil {
+			b.log.Errorf("binding: %s, replay, %s", b.name, err.Error())
+		}
+	default:
+		if !b.journal.Enabled() {
+			return nil, fmt.Errorf("%w, %s, set journal_size binding property", ErrJournalDisabled, b.name)
+		}
+		items = b.journalItems(req)
+	}
+	result := &ReplayResult{
+		Binding: b.name,
+		Source:  req.Source,
+		DryRun:  req.DryRun,
+		Total:   len(items),
+	}
+	limiter := ratelimit.NewUnlimited()
+	if req.RatePerSecond > 0 {
+		limiter = ratelimit.New(req.RatePerSecond, ratelimit.WithoutSlack)
+	}
+	var unprocessed []*types.DeadLetter
+	for _, item := range items {
+		outcome := &ReplayOutcome{
+			Id:        item.id,
+			CreatedAt: item.createdAt,
+		}
+		result.Outcomes = append(result.Outcomes, outcome)
+		if req.DryRun {
+			outcome.Status = ReplayOutcomeDryRun
+			continue
+		}
+		if ctx.Err() != nil {
+			outcome.Status = ReplayOutcomeError
+			outcome.Error = ctx.Err().Error()
+			result.Failed++
+			if item.deadLetter != nil {
+				unprocessed = append(unprocessed, item.deadLetter)
+			}
+			continue
+		}
+		limiter.Take()
+		resp, err := b.md.Do(middleware.WithReplayOf(ctx, item.id), middleware.CopyRequest(item.request))
+		if resp != nil {
+			outcome.Response = toResponse(resp)
+		}
+		if err != nil {
+			outcome.Status = ReplayOutcomeError
+			outcome.Error = err.Error()
+			result.Failed++
+			continue
+		}
+		outcome.Status = ReplayOutcomeSuccess
+		result.Succeeded++
+	}
+	// dead letters taken from the queue but not replayed are sent back, so they can be replayed later
+	if err := b.dl.Requeue(unprocessed); err != nil {
+		b.log.Errorf("binding: %s, replay, %s", b.name, err.Error())
+	}
+	return result, nil
+}
@@ -0,0 +1,308 @@
+package binding
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/kubemq-io/kubemq-targets/config"
+	"github.com/kubemq-io/kubemq-targets/pkg/journal"
+	"github.com/kubemq-io/kubemq-targets/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplayRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     *ReplayRequest
+		want    *ReplayRequest
+		wantErr bool
+	}{
+		{
+			name: "default values",
+			req:  &ReplayRequest{},
+			want: &ReplayRequest{
+				Source: ReplaySourceJournal,
+				Status: types.ActivityStatusError,
+				Limit:  defaultReplayLimit,
+			},
+		},
+		{
+			name: "dead letter source",
+			req: &ReplayRequest{
+				Source: ReplaySourceDeadLetter,
+				Status: ReplayStatusAll,
+				Limit:  10,
+			},
+			want: &ReplayRequest{
+				Source: ReplaySourceDeadLetter,
+				Status: ReplayStatusAll,
+				Limit:  10,
+			},
+		},
+		{
+			name:    "invalid source",
+			req:     &ReplayRequest{Source: "bad-source"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid status",
+			req:     &ReplayRequest{Status: "bad-status"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid limit",
+			req:     &ReplayRequest{Limit: maxReplayLimit + 1},
+			wantErr: true,
+		},
+		{
+			name:    "invalid rate per second",
+			req:     &ReplayRequest{RatePerSecond: -1},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, tt.req)
+		})
+	}
+}
+
+// newReplayBinder returns a binder whose journal holds requests 1 to 4, the even ones failed
+func newReplayBinder(t *testing.T) *Binder {
+	cfg := newTestBindingConfig("replay", false)
+	cfg.Properties["journal_size"] = "100"
+	b := newTestBinder(t, cfg, &mockSource{}, &mockTarget{})
+	for i := 1; i <= 4; i++ {
+		request := types.NewRequest().SetData([]byte(fmt.Sprintf("%d", i)))
+		if i%2 == 0 {
+			request.SetMetadataKeyValue("fail", "true")
+		}
+		_, _ = b.md.Do(context.Background(), request)
+	}
+	return b
+}
+
+func TestBinder_Replay(t *testing.T) {
+	tests := []struct {
+		name          string
+		req           *ReplayRequest
+		cancel        bool
+		wantIds       []int64
+		wantSucceeded int
+		wantFailed    int
+		wantStatus    string
+	}{
+		{
+			name:       "failed requests by default",
+			req:        &ReplayRequest{},
+			wantIds:    []int64{2, 4},
+			wantFailed: 2,
+			wantStatus: ReplayOutcomeError,
+		},
+		{
+			name:          "succeeded requests",
+			req:           &ReplayRequest{Status: types.ActivityStatusSuccess},
+			wantIds:       []int64{1, 3},
+			wantSucceeded: 2,
+			wantStatus:    ReplayOutcomeSuccess,
+		},
+		{
+			name:          "all requests oldest first",
+			req:           &ReplayRequest{Status: ReplayStatusAll},
+			wantIds:       []int64{1, 2, 3, 4},
+			wantSucceeded: 2,
+			wantFailed:    2,
+		},
+		{
+			name:          "selected ids",
+			req:           &ReplayRequest{Status: ReplayStatusAll, Ids: []int64{3}},
+			wantIds:       []int64{3},
+			wantSucceeded: 1,
+			wantStatus:    ReplayOutcomeSuccess,
+		},
+		{
+			name:          "limit keeps the newest requests",
+			req:           &ReplayRequest{Status: ReplayStatusAll, Limit: 2},
+			wantIds:       []int64{3, 4},
+			wantSucceeded: 1,
+			wantFailed:    1,
+		},
+		{
+			name:       "dry run",
+			req:        &ReplayRequest{Status: ReplayStatusAll, DryRun: true},
+			wantIds:    []int64{1, 2, 3, 4},
+			wantStatus: ReplayOutcomeDryRun,
+		},
+		{
+			name:       "cancelled context",
+			req:        &ReplayRequest{Status: types.ActivityStatusSuccess},
+			cancel:     true,
+			wantIds:    []int64{1, 3},
+			wantFailed: 2,
+			wantStatus: ReplayOutcomeError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newReplayBinder(t)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.cancel {
+				cancel()
+			}
+			require.NoError(t, tt.req.Validate())
+			result, err := b.Replay(ctx, tt.req)
+			require.NoError(t, err)
+			require.Equal(t, len(tt.wantIds), result.Total)
+			require.Equal(t, tt.wantSucceeded, result.Succeeded)
+			require.Equal(t, tt.wantFailed, result.Failed)
+			var ids []int64
+			for _, outcome := range result.Outcomes {
+				ids = append(ids, outcome.Id)
+				if tt.wantStatus != "" {
+					require.Equal(t, tt.wantStatus, outcome.Status)
+				}
+			}
+			require.Equal(t, tt.wantIds, ids)
+		})
+	}
+}
+
+func TestService_Replay(t *testing.T) {
+	s := newTestService(t)
+	journalCfg := newTestBindingConfig("journal", false)
+	journalCfg.Properties["journal_size"] = "100"
+	addTestBinding(s, journalCfg, newTestBinder(t, journalCfg, &mockSource{}, &mockTarget{}))
+	noJournalCfg := newTestBindingConfig("no-journal", false)
+	addTestBinding(s, noJournalCfg, newTestBinder(t, noJournalCfg, &mockSource{}, &mockTarget{}))
+	addTestBinding(s, newTestBindingConfig("paused", true), nil)
+	tests := []struct {
+		name    string
+		binding string
+		req     *ReplayRequest
+		wantErr error
+	}{
+		{
+			name:    "journal replay",
+			binding: "journal",
+			req:     &ReplayRequest{},
+		},
+		{
+			name:    "invalid request",
+			binding: "journal",
+			req:     &ReplayRequest{Source: "bad-source"},
+			wantErr: ErrInvalidReplay,
+		},
+		{
+			name:    "binding not found",
+			binding: "missing",
+			req:     &ReplayRequest{},
+			wantErr: ErrBindingNotFound,
+		},
+		{
+			name:    "binding not running",
+			binding: "paused",
+			req:     &ReplayRequest{},
+			wantErr: ErrNotRunning,
+		},
+		{
+			name:    "journal disabled",
+			binding: "no-journal",
+			req:     &ReplayRequest{},
+			wantErr: ErrJournalDisabled,
+		},
+		{
+			name:    "no dead letter channel",
+			binding: "journal",
+			req:     &ReplayRequest{Source: ReplaySourceDeadLetter},
+			wantErr: ErrInvalidReplay,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := s.Replay(context.Background(), tt.binding, tt.req)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.binding, result.Binding)
+		})
+	}
+}
+
+func TestService_Activity(t *testing.T) {
+	s := newTestService(t)
+	cfg := newTestBindingConfig("activity", false)
+	cfg.Properties["journal_size"] = "100"
+	cfg.Properties["log_redact_fields"] = "password"
+	binder := newTestBinder(t, cfg, &mockSource{}, &mockTarget{})
+	addTestBinding(s, cfg, binder)
+	_, err := binder.md.Do(context.Background(), types.NewRequest().SetMetadataKeyValue("password", "secret"))
+	require.NoError(t, err)
+	list, err := s.Activity("activity", journal.Filter{})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.NotEqual(t, "secret", list[0].Request.Metadata["password"])
+	_, err = s.Activity("missing", journal.Filter{})
+	require.ErrorIs(t, err, ErrBindingNotFound)
+	addTestBinding(s, config.BindingConfig{Name: "paused", Paused: true}, nil)
+	_, err = s.Activity("paused", journal.Filter{})
+	require.ErrorIs(t, err, ErrNotRunning)
+}
+
+func TestBinder_ReplayTwice(t *testing.T) {
+	tests := []struct {
+		name        string
+		req         *ReplayRequest
+		wantIds     []int64
+		wantIdsNext []int64
+	}{
+		{
+			name:        "failed requests are replayed until they succeed",
+			req:         &ReplayRequest{},
+			wantIds:     []int64{2, 4},
+			wantIdsNext: []int64{2, 4},
+		},
+		{
+			name:    "succeeded replays are not replayed again",
+			req:     &ReplayRequest{Status: types.ActivityStatusSuccess},
+			wantIds: []int64{1, 3},
+		},
+		{
+			name:        "replays of all requests",
+			req:         &ReplayRequest{Status: ReplayStatusAll},
+			wantIds:     []int64{1, 2, 3, 4},
+			wantIdsNext: []int64{2, 4},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newReplayBinder(t)
+			require.NoError(t, tt.req.Validate())
+			for _, wantIds := range [][]int64{tt.wantIds, tt.wantIdsNext} {
+				result, err := b.Replay(context.Background(), tt.req)
+				require.NoError(t, err)
+				var ids []int64
+				for _, outcome := range result.Outcomes {
+					ids = append(ids, outcome.Id)
+				}
+				require.Equal(t, wantIds, ids)
+			}
+			for _, activity := range b.journal.List(journal.Filter{}) {
+				if activity.Id > 4 {
+					require.NotZero(t, activity.ReplayOf)
+				} else {
+					require.Zero(t, activity.ReplayOf)
+				}
+			}
+		})
+	}
+}
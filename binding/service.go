@@ -2,29 +2,52 @@ package binding
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"sync"
 	"time"
 
 	"github.com/kubemq-io/kubemq-targets/config"
+	"github.com/kubemq-io/kubemq-targets/middleware"
+	"github.com/kubemq-io/kubemq-targets/pkg/journal"
 	"github.com/kubemq-io/kubemq-targets/pkg/logger"
 	"github.com/kubemq-io/kubemq-targets/pkg/metrics"
 	"github.com/kubemq-io/kubemq-targets/types"
 )
 
-const (
-	addRetryInterval = 1 * time.Second
+var (
+	ErrBindingNotFound = errors.New("binding not found")
+	ErrBindingExists   = errors.New("binding already exists")
+	ErrInvalidBinding  = errors.New("invalid binding config")
+	ErrNotRunning      = errors.New("binding is not running")
+	ErrJournalDisabled = errors.New("binding journal is disabled")
+	ErrInvalidReplay   = errors.New("invalid replay request")
+	ErrBindingRunning  = errors.New("binding is already running")
+	ErrBindingPaused   = errors.New("binding is paused")
+	ErrBindingChanged  = errors.New("binding was changed meanwhile")
+	errBindingChanged  = errors.New("binding changed")
 )
 
 type Service struct {
 	bindings          sync.Map
 	log               *logger.Logger
 	exporter          *metrics.Exporter
+	bindingsCtx       context.Context
 	currentCtx        context.Context
 	currentCancelFunc context.CancelFunc
 	bindingStatus     sync.Map
-	cfg               *config.Config
+	// stopping holds the binders drained outside of mu, they are reported by the status until stopped
+	stopping      sync.Map
+	cfg           *config.Config
+	secrets       *config.SecretResolver
+	secretsCancel context.CancelFunc
+	// retries holds the retry now channels of the bindings waiting for their next initialization attempt
+	retries map[string]chan struct{}
+	retryMu sync.Mutex
+	// mu serializes bindings changes, cfgMu guards the bindings list of cfg read by the status api
+	mu    sync.Mutex
+	cfgMu sync.RWMutex
 }
 
 func New() (*Service, error) {
@@ -32,6 +55,7 @@ func New() (*Service, error) {
 		bindings:      sync.Map{},
 		log:           logger.NewLogger("binding-service"),
 		bindingStatus: sync.Map{},
+		retries:       map[string]chan struct{}{},
 	}
 	var err error
 	s.exporter, err = metrics.NewExporter()
@@ -42,80 +66,380 @@ func New() (*Service, error) {
 }
 
 func (s *Service) Start(ctx context.Context, cfg *config.Config) error {
+	s.bindingsCtx = ctx
 	s.currentCtx, s.currentCancelFunc = context.WithCancel(ctx)
-	s.cfg = cfg
-	if len(cfg.Bindings) == 0 {
-		return nil
+	secrets, err := config.NewSecretResolver(ctx, cfg.Secrets)
+	if err != nil {
+		s.currentCancelFunc()
+		return fmt.Errorf("error initializing secret providers, %w", err)
 	}
+	s.secrets = secrets
+	s.cfgMu.Lock()
+	s.cfg = cfg
+	s.cfgMu.Unlock()
 	for _, bindingCfg := range cfg.Bindings {
-		go func(ctx context.Context, cfg config.BindingConfig) {
-			err := s.Add(ctx, cfg)
-			if err == nil {
+		s.startBinding(bindingCfg)
+	}
+	s.watchSecrets(cfg.Secrets.RefreshInterval())
+	return nil
+}
+
+// watchSecrets replaces the running secrets watcher with one resolving the bindings secrets every interval, none if zero
+func (s *Service) watchSecrets(interval time.Duration) {
+	if s.secretsCancel != nil {
+		s.secretsCancel()
+		s.secretsCancel = nil
+	}
+	if interval <= 0 {
+		return
+	}
+	var ctx context.Context
+	ctx, s.secretsCancel = context.WithCancel(s.currentCtx)
+	go func() {
+		for {
+			select {
+			case <-time.After(interval):
+				s.refreshSecrets(ctx)
+			case <-ctx.Done():
 				return
-			} else {
-				s.log.Errorf("failed to initialized binding, %s", err.Error())
 			}
-			count := 0
-			for {
-				select {
-				case <-time.After(addRetryInterval):
-					count++
-					err := s.Add(ctx, cfg)
-					if err != nil {
-						s.log.Errorf("failed to initialized binding: %s, attempt: %d, error: %s", cfg.Name, count, err.Error())
-					} else {
-						return
-					}
-				case <-ctx.Done():
-					return
-				}
+		}
+	}()
+}
+
+// refreshSecrets resolves again the secrets of the running bindings and restarts the ones whose secrets were rotated
+func (s *Service) refreshSecrets(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ctx.Err() != nil {
+		return
+	}
+	// the lock is released while draining, the bindings changed meanwhile are skipped
+	bindings := append([]config.BindingConfig{}, s.cfg.Bindings...)
+	for _, bindingCfg := range bindings {
+		val, ok := s.bindings.Load(bindingCfg.Name)
+		if !ok {
+			continue
+		}
+		resolved, err := s.secrets.ResolveBinding(ctx, bindingCfg)
+		if err != nil {
+			s.log.Errorf("error resolving secrets, %s", err.Error())
+			continue
+		}
+		if resolved.Equal(val.(*Binder).resolved) {
+			continue
+		}
+		s.log.Infof("binding %s secrets rotated, restarting", bindingCfg.Name)
+		if err := s.drain(bindingCfg.Name); err != nil {
+			s.log.Error(err)
+		}
+		if _, err := s.unchangedIndex(bindingCfg); err != nil {
+			s.log.Infof("binding %s not restarted, %s", bindingCfg.Name, err.Error())
+			continue
+		}
+		if err := s.Add(s.bindingsCtx, bindingCfg); err != nil {
+			s.log.Errorf("failed to initialized binding, %s", err.Error())
+			s.startBinding(bindingCfg)
+		}
+	}
+}
+
+// startBinding starts a binding of the current config in the background, retrying with backoff until it is initialized
+func (s *Service) startBinding(cfg config.BindingConfig) {
+	if cfg.Paused {
+		s.bindingStatus.Store(cfg.Name, newStatus(cfg))
+		return
+	}
+	go func(ctx context.Context, cfg config.BindingConfig) {
+		backoff, err := newInitBackoff(cfg.Properties)
+		if err != nil {
+			s.log.Errorf("failed to initialized binding: %s, error: %s", cfg.Name, err.Error())
+			s.setFailed(cfg, err)
+			return
+		}
+		retry := s.addRetry(cfg.Name)
+		defer s.removeRetry(cfg.Name, retry)
+		for attempt := 1; ; attempt++ {
+			err := s.addPending(ctx, cfg)
+			if err == nil || errors.Is(err, errBindingChanged) {
+				return
+			}
+			if backoff.exhausted(attempt) {
+				s.log.Errorf("failed to initialized binding: %s, attempt: %d, giving up, error: %s", cfg.Name, attempt, err.Error())
+				s.setFailed(cfg, nil)
+				return
+			}
+			delay := backoff.delay(attempt)
+			s.log.Errorf("failed to initialized binding: %s, attempt: %d, next attempt in: %s, error: %s", cfg.Name, attempt, delay.Round(time.Millisecond), err.Error())
+			select {
+			case <-time.After(delay):
+			case <-retry:
+				s.log.Infof("binding %s retry requested", cfg.Name)
+			case <-ctx.Done():
+				return
 			}
-		}(s.currentCtx, bindingCfg)
+		}
+	}(s.currentCtx, cfg)
+}
+
+// addRetry registers the retry now channel of the named binding
+func (s *Service) addRetry(name string) chan struct{} {
+	s.retryMu.Lock()
+	defer s.retryMu.Unlock()
+	retry := make(chan struct{}, 1)
+	s.retries[name] = retry
+	return retry
+}
+
+// removeRetry unregisters the retry now channel of the named binding, unless a newer one replaced it
+func (s *Service) removeRetry(name string, retry chan struct{}) {
+	s.retryMu.Lock()
+	defer s.retryMu.Unlock()
+	if s.retries[name] == retry {
+		delete(s.retries, name)
 	}
-	return nil
 }
 
-func (s *Service) Stop() {
-	s.currentCancelFunc()
-	s.bindings.Range(func(key, value interface{}) bool {
-		binder := value.(*Binder)
-		err := s.Remove(binder.name)
+// setFailed marks a binding of the loaded config as failed, it is not retried until requested through the management api
+func (s *Service) setFailed(cfg config.BindingConfig, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	index := s.bindingIndex(cfg.Name)
+	if index < 0 || !s.cfg.Bindings[index].Equal(cfg) {
+		return
+	}
+	if _, ok := s.bindings.Load(cfg.Name); ok {
+		return
+	}
+	status := newStatus(cfg)
+	if val, ok := s.bindingStatus.Load(cfg.Name); ok {
+		status = val.(*Status)
+	}
+	if err != nil {
+		status = status.failed(err)
+	}
+	s.bindingStatus.Store(cfg.Name, status.stopped())
+}
+
+// Reload applies a new config, only the bindings added, removed or changed since the current config are stopped or started
+func (s *Service) Reload(cfg *config.Config) {
+	s.mu.Lock()
+	current := map[string]config.BindingConfig{}
+	for _, bindingCfg := range s.cfg.Bindings {
+		current[bindingCfg.Name] = bindingCfg
+	}
+	next := map[string]bool{}
+	stopped := map[string]*Binder{}
+	var changed []config.BindingConfig
+	var removed []string
+	for _, bindingCfg := range cfg.Bindings {
+		next[bindingCfg.Name] = true
+		if old, ok := current[bindingCfg.Name]; ok {
+			if old.Equal(bindingCfg) {
+				continue
+			}
+			s.log.Infof("binding %s changed, restarting", bindingCfg.Name)
+		} else {
+			s.log.Infof("binding %s added", bindingCfg.Name)
+		}
+		s.detach(bindingCfg.Name, stopped)
+		changed = append(changed, bindingCfg)
+	}
+	for name := range current {
+		if !next[name] {
+			s.detach(name, stopped)
+			removed = append(removed, name)
+			s.log.Infof("binding %s removed", name)
+		}
+	}
+	secretsChanged := s.cfg.Secrets != cfg.Secrets
+	if secretsChanged {
+		secrets, err := config.NewSecretResolver(s.currentCtx, cfg.Secrets)
 		if err != nil {
+			s.log.Errorf("error initializing secret providers, keeping current providers, %s", err.Error())
+			secretsChanged = false
+		} else {
+			s.secrets = secrets
+			s.watchSecrets(cfg.Secrets.RefreshInterval())
+		}
+	}
+	s.cfgMu.Lock()
+	s.cfg = cfg
+	s.cfgMu.Unlock()
+	s.mu.Unlock()
+	// draining may take up to the drain timeout, the management api is not blocked meanwhile
+	errs := s.stopBinders(stopped)
+	s.mu.Lock()
+	for _, name := range removed {
+		if s.bindingIndex(name) < 0 {
+			s.bindingStatus.Delete(name)
+		}
+	}
+	for _, bindingCfg := range changed {
+		if _, ok := s.bindings.Load(bindingCfg.Name); ok {
+			// started through the management api meanwhile
+			continue
+		}
+		status := newStatus(bindingCfg)
+		if err, ok := errs[bindingCfg.Name]; ok {
 			s.log.Error(err)
+			status = status.failed(err)
+		}
+		s.bindingStatus.Store(bindingCfg.Name, status)
+		if !bindingCfg.Paused {
+			s.startBinding(bindingCfg)
+		}
+	}
+	s.mu.Unlock()
+	if secretsChanged {
+		s.refreshSecrets(s.currentCtx)
+	}
+}
+
+// detach removes the named binder from the running bindings into stopped, it is reported as stopping until stopBinder returns
+func (s *Service) detach(name string, stopped map[string]*Binder) {
+	val, ok := s.bindings.Load(name)
+	if !ok {
+		return
+	}
+	s.bindings.Delete(name)
+	s.stopping.Store(name, val)
+	stopped[name] = val.(*Binder)
+}
+
+// stopBinder drains and stops a detached binder, the stop error is recorded in the binding status
+func (s *Service) stopBinder(binder *Binder) error {
+	err := binder.Stop()
+	if val, ok := s.stopping.Load(binder.name); ok && val.(*Binder) == binder {
+		s.stopping.Delete(binder.name)
+	}
+	if err != nil {
+		if val, ok := s.bindingStatus.Load(binder.name); ok {
+			s.bindingStatus.Store(binder.name, val.(*Status).failed(err))
 		}
+	}
+	return err
+}
+
+// stopBinders stops the detached binders concurrently and returns the stop errors by binding name
+func (s *Service) stopBinders(binders map[string]*Binder) map[string]error {
+	errs := map[string]error{}
+	errsMu := sync.Mutex{}
+	wg := sync.WaitGroup{}
+	for name, binder := range binders {
+		wg.Add(1)
+		go func(name string, binder *Binder) {
+			defer wg.Done()
+			if err := s.stopBinder(binder); err != nil {
+				errsMu.Lock()
+				errs[name] = err
+				errsMu.Unlock()
+			}
+		}(name, binder)
+	}
+	wg.Wait()
+	return errs
+}
+
+// Stop drains and stops all bindings concurrently
+func (s *Service) Stop() {
+	s.currentCancelFunc()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stopped := map[string]*Binder{}
+	s.bindings.Range(func(key, value interface{}) bool {
+		s.detach(key.(string), stopped)
 		return true
 	})
+	for _, err := range s.stopBinders(stopped) {
+		s.log.Error(err)
+	}
 }
 
 func (s *Service) Add(ctx context.Context, cfg config.BindingConfig) error {
+	ctx, cancel := context.WithCancel(ctx)
 	binder := NewBinder()
+	binder.cancel = cancel
 	status := newStatus(cfg)
+	// init attempts and failures of the same binding config are kept across retries
+	if val, ok := s.bindingStatus.Load(cfg.Name); ok {
+		last := val.(*Status)
+		status.InitAttempts = last.InitAttempts
+		status.LastError = last.LastError
+		status.LastFailureAt = last.LastFailureAt
+	}
+	status.InitAttempts++
 	s.bindingStatus.Store(cfg.Name, status)
-	err := binder.Init(ctx, cfg, s.exporter)
-	if err != nil {
+	fail := func(err error) error {
+		// the targets and clients initialized so far are released before the next attempt
+		if errStop := binder.teardown(); errStop != nil {
+			s.log.Error(errStop)
+		}
+		s.bindingStatus.Store(cfg.Name, status.failed(redactError(err, binder.resolved)))
 		return err
 	}
+	resolved, err := s.secrets.ResolveBinding(ctx, cfg)
+	if err != nil {
+		binder.resolved = cfg
+		return fail(err)
+	}
+	binder.resolved = resolved
+	err = binder.Init(ctx, resolved, s.exporter)
+	if err != nil {
+		return fail(err)
+	}
 	err = binder.Start(ctx)
 	if err != nil {
-		return err
+		return fail(err)
 	}
 	s.bindings.Store(cfg.Name, binder)
-	status.Ready = true
-	s.bindingStatus.Store(cfg.Name, status)
+	s.bindingStatus.Store(cfg.Name, status.running())
 	return nil
 }
 
+// drain detaches and stops the named binding, it is called with the service lock held which is released while the
+// binding drains, as draining may take up to the drain timeout. It returns with the lock held and no binder of the binding running
+func (s *Service) drain(name string) error {
+	var err error
+	for {
+		stopped := map[string]*Binder{}
+		s.detach(name, stopped)
+		binder, ok := stopped[name]
+		if !ok {
+			return err
+		}
+		s.mu.Unlock()
+		stopErr := s.stopBinder(binder)
+		s.mu.Lock()
+		if stopErr != nil {
+			err = stopErr
+		}
+	}
+}
+
+// unchangedIndex returns the index of the binding in the current config, failing if it was removed or changed since cfg
+func (s *Service) unchangedIndex(cfg config.BindingConfig) (int, error) {
+	index := s.bindingIndex(cfg.Name)
+	if index < 0 {
+		return index, fmt.Errorf("%w, %s", ErrBindingNotFound, cfg.Name)
+	}
+	if !s.cfg.Bindings[index].Equal(cfg) {
+		return index, fmt.Errorf("%w, %s", ErrBindingChanged, cfg.Name)
+	}
+	return index, nil
+}
+
+// Remove drains and stops the named binding, the binding is removed even if it failed to stop
 func (s *Service) Remove(name string) error {
-	val, ok := s.bindings.Load(name)
+	stopped := map[string]*Binder{}
+	s.detach(name, stopped)
+	binder, ok := stopped[name]
 	if !ok {
 		return fmt.Errorf("binding %s not found", name)
 	}
-	binder := val.(*Binder)
-	err := binder.Stop()
-	if err != nil {
+	if err := s.stopBinder(binder); err != nil {
 		return err
 	}
-	s.bindings.Delete(name)
 	s.bindingStatus.Delete(name)
 	return nil
 }
@@ -129,16 +453,90 @@ func (s *Service) Stats() []*metrics.Report {
 }
 
 func (s *Service) GetStatus() []*Status {
+	s.cfgMu.RLock()
+	defer s.cfgMu.RUnlock()
 	var list []*Status
 	for _, binding := range s.cfg.Bindings {
 		val, ok := s.bindingStatus.Load(binding.Name)
 		if ok {
-			list = append(list, val.(*Status))
+			status := *val.(*Status)
+			if binder, ok := s.binder(binding.Name); ok {
+				if binder.cb != nil {
+					status.CircuitBreaker = string(binder.cb.State())
+				}
+				status.Draining = binder.Draining()
+				status.InFlight = binder.InFlight()
+				status.Health = binder.health.get()
+				binder.setRequestsStatus(&status)
+				switch {
+				case status.Draining:
+					status.Ready = false
+					status.State = StateStopping
+				case !status.Health.Healthy || status.CircuitBreaker == string(middleware.CircuitBreakerStateOpen):
+					status.State = StateDegraded
+				}
+				if status.StartedAt != nil {
+					status.Uptime = time.Since(*status.StartedAt).Round(time.Second).String()
+				}
+			}
+			list = append(list, &status)
 		}
 	}
 	return list
 }
 
+// binder returns the binder of the named binding, running or stopping
+func (s *Service) binder(name string) (*Binder, bool) {
+	if val, ok := s.bindings.Load(name); ok {
+		return val.(*Binder), true
+	}
+	if val, ok := s.stopping.Load(name); ok {
+		return val.(*Binder), true
+	}
+	return nil, false
+}
+
+// Activity returns the journal activities of the named binding matching the filter, newest first
+func (s *Service) Activity(name string, filter journal.Filter) ([]*types.Activity, error) {
+	binder, err := s.runningBinder(name)
+	if err != nil {
+		return nil, err
+	}
+	if !binder.journal.Enabled() {
+		return nil, fmt.Errorf("%w, %s, set journal_size binding property", ErrJournalDisabled, name)
+	}
+	return binder.Activity(filter), nil
+}
+
+// runningBinder returns the binder of the named binding, failing if it is not found or not running
+func (s *Service) runningBinder(name string) (*Binder, error) {
+	val, ok := s.bindings.Load(name)
+	if ok {
+		return val.(*Binder), nil
+	}
+	s.cfgMu.RLock()
+	defer s.cfgMu.RUnlock()
+	if s.bindingIndex(name) < 0 {
+		return nil, fmt.Errorf("%w, %s", ErrBindingNotFound, name)
+	}
+	return nil, fmt.Errorf("%w, %s", ErrNotRunning, name)
+}
+
+// Replay executes again the journal or dead letter requests of the named binding selected by req
+func (s *Service) Replay(ctx context.Context, name string, req *ReplayRequest) (*ReplayResult, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%w, %s", ErrInvalidReplay, err.Error())
+	}
+	binder, err := s.runningBinder(name)
+	if err != nil {
+		return nil, err
+	}
+	if req.Source == ReplaySourceDeadLetter && !binder.dl.Enabled() {
+		return nil, fmt.Errorf("%w, binding %s has no dead letter channel", ErrInvalidReplay, name)
+	}
+	return binder.Replay(ctx, req)
+}
+
 func (s *Service) SendRequest(ctx context.Context, req *Request) *Response {
 	val, ok := s.bindings.Load(req.Binding)
 	if !ok {
@@ -149,9 +547,226 @@ func (s *Service) SendRequest(ctx context.Context, req *Request) *Response {
 		return toResponse(types.NewResponse().SetError(fmt.Errorf("error during parsing request: %s", err.Error())))
 	}
 	binder := val.(*Binder)
-	resp, err := binder.md.Do(ctx, r)
+	resp, err := middleware.Chain(binder.md, middleware.StripRouterMetadata).Do(ctx, r)
 	if err != nil {
 		return toResponse(types.NewResponse().SetError(fmt.Errorf("error during executing request: %s", err.Error())))
 	}
 	return toResponse(resp)
 }
+
+// addPending starts a binding of the loaded config, unless it was changed through the management api meanwhile
+func (s *Service) addPending(ctx context.Context, cfg config.BindingConfig) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ctx.Err() != nil {
+		return errBindingChanged
+	}
+	index := s.bindingIndex(cfg.Name)
+	if index < 0 || s.cfg.Bindings[index].Paused || !s.cfg.Bindings[index].Equal(cfg) {
+		return errBindingChanged
+	}
+	if _, ok := s.bindings.Load(cfg.Name); ok {
+		return errBindingChanged
+	}
+	return s.Add(s.bindingsCtx, cfg)
+}
+
+// setBindings replaces the bindings list of the current config
+func (s *Service) setBindings(bindings []config.BindingConfig) {
+	s.cfgMu.Lock()
+	defer s.cfgMu.Unlock()
+	s.cfg.Bindings = bindings
+}
+
+func (s *Service) replaceBinding(index int, cfg config.BindingConfig) {
+	s.cfgMu.Lock()
+	defer s.cfgMu.Unlock()
+	s.cfg.Bindings[index] = cfg
+}
+
+// bindingIndex returns the index of the named binding in the current config, or -1 if none
+func (s *Service) bindingIndex(name string) int {
+	for i, binding := range s.cfg.Bindings {
+		if binding.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *Service) saveConfig() error {
+	if err := config.Save(s.cfg); err != nil {
+		s.log.Errorf("error saving bindings config, %s", err.Error())
+		return fmt.Errorf("binding changes applied but not saved, %w", err)
+	}
+	return nil
+}
+
+// CreateBinding validates, starts and saves a new binding
+func (s *Service) CreateBinding(cfg config.BindingConfig) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("%w, %s", ErrInvalidBinding, err.Error())
+	}
+	if s.bindingIndex(cfg.Name) >= 0 {
+		return fmt.Errorf("%w, %s", ErrBindingExists, cfg.Name)
+	}
+	if cfg.Paused {
+		s.bindingStatus.Store(cfg.Name, newStatus(cfg))
+	} else if err := s.Add(s.bindingsCtx, cfg); err != nil {
+		s.bindingStatus.Delete(cfg.Name)
+		return err
+	}
+	s.setBindings(append(s.cfg.Bindings, cfg))
+	s.log.Infof("binding %s created", cfg.Name)
+	return s.saveConfig()
+}
+
+// UpdateBinding replaces the named binding with cfg, the previous binding is restored if cfg cannot be started
+func (s *Service) UpdateBinding(name string, cfg config.BindingConfig) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cfg.Name = name
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("%w, %s", ErrInvalidBinding, err.Error())
+	}
+	index := s.bindingIndex(name)
+	if index < 0 {
+		return fmt.Errorf("%w, %s", ErrBindingNotFound, name)
+	}
+	current := s.cfg.Bindings[index]
+	if err := s.drain(name); err != nil {
+		s.log.Error(err)
+	}
+	index, err := s.unchangedIndex(current)
+	if err != nil {
+		return err
+	}
+	if cfg.Paused {
+		s.bindingStatus.Store(cfg.Name, newStatus(cfg))
+	} else if err := s.Add(s.bindingsCtx, cfg); err != nil {
+		s.restore(current)
+		return err
+	}
+	s.replaceBinding(index, cfg)
+	s.log.Infof("binding %s updated", name)
+	return s.saveConfig()
+}
+
+// restore starts back a binding which failed to be replaced
+func (s *Service) restore(cfg config.BindingConfig) {
+	if cfg.Paused {
+		s.bindingStatus.Store(cfg.Name, newStatus(cfg))
+		return
+	}
+	if err := s.Add(s.bindingsCtx, cfg); err != nil {
+		s.log.Errorf("error restoring binding %s, %s", cfg.Name, err.Error())
+	}
+}
+
+// DeleteBinding stops the named binding and removes it from the saved config
+func (s *Service) DeleteBinding(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	index := s.bindingIndex(name)
+	if index < 0 {
+		return fmt.Errorf("%w, %s", ErrBindingNotFound, name)
+	}
+	current := s.cfg.Bindings[index]
+	if err := s.drain(name); err != nil {
+		s.log.Error(err)
+	}
+	index, err := s.unchangedIndex(current)
+	if err != nil {
+		return err
+	}
+	s.bindingStatus.Delete(name)
+	bindings := append([]config.BindingConfig{}, s.cfg.Bindings[:index]...)
+	s.setBindings(append(bindings, s.cfg.Bindings[index+1:]...))
+	s.log.Infof("binding %s deleted", name)
+	return s.saveConfig()
+}
+
+// PauseBinding stops the named binding and keeps it paused across restarts
+func (s *Service) PauseBinding(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	index := s.bindingIndex(name)
+	if index < 0 {
+		return fmt.Errorf("%w, %s", ErrBindingNotFound, name)
+	}
+	cfg := s.cfg.Bindings[index]
+	if cfg.Paused {
+		return nil
+	}
+	stopErr := s.drain(name)
+	if stopErr != nil {
+		s.log.Error(stopErr)
+	}
+	index, err := s.unchangedIndex(cfg)
+	if err != nil {
+		return err
+	}
+	cfg.Paused = true
+	status := newStatus(cfg)
+	if stopErr != nil {
+		status = status.failed(stopErr)
+	}
+	s.bindingStatus.Store(name, status)
+	s.replaceBinding(index, cfg)
+	s.log.Infof("binding %s paused", name)
+	return s.saveConfig()
+}
+
+// RetryBinding triggers an initialization attempt of the named binding now, restarting the attempts of a failed binding
+func (s *Service) RetryBinding(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	index := s.bindingIndex(name)
+	if index < 0 {
+		return fmt.Errorf("%w, %s", ErrBindingNotFound, name)
+	}
+	cfg := s.cfg.Bindings[index]
+	if cfg.Paused {
+		return fmt.Errorf("%w, %s", ErrBindingPaused, name)
+	}
+	if _, ok := s.bindings.Load(name); ok {
+		return fmt.Errorf("%w, %s", ErrBindingRunning, name)
+	}
+	s.retryMu.Lock()
+	retry, ok := s.retries[name]
+	s.retryMu.Unlock()
+	if ok {
+		select {
+		case retry <- struct{}{}:
+		default:
+		}
+		return nil
+	}
+	s.log.Infof("binding %s retry requested", name)
+	s.startBinding(cfg)
+	return nil
+}
+
+// ResumeBinding starts a paused binding
+func (s *Service) ResumeBinding(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	index := s.bindingIndex(name)
+	if index < 0 {
+		return fmt.Errorf("%w, %s", ErrBindingNotFound, name)
+	}
+	cfg := s.cfg.Bindings[index]
+	if !cfg.Paused {
+		return nil
+	}
+	cfg.Paused = false
+	if err := s.Add(s.bindingsCtx, cfg); err != nil {
+		s.bindingStatus.Store(name, newStatus(s.cfg.Bindings[index]))
+		return err
+	}
+	s.replaceBinding(index, cfg)
+	s.log.Infof("binding %s resumed", name)
+	return s.saveConfig()
+}
@@ -0,0 +1,468 @@
+package binding
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kubemq-hub/builder/connector/common"
+	"github.com/kubemq-io/kubemq-targets/config"
+	"github.com/kubemq-io/kubemq-targets/middleware"
+	"github.com/kubemq-io/kubemq-targets/pkg/logger"
+	"github.com/kubemq-io/kubemq-targets/pkg/metrics"
+	"github.com/kubemq-io/kubemq-targets/targets"
+	"github.com/kubemq-io/kubemq-targets/types"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+type mockSource struct {
+	mu        sync.Mutex
+	stopErr   error
+	stopped   bool
+	stopBlock chan struct{}
+}
+
+func (m *mockSource) Init(ctx context.Context, cfg config.Spec, log *logger.Logger) error {
+	return nil
+}
+
+func (m *mockSource) Start(ctx context.Context, target middleware.Middleware) error {
+	return nil
+}
+
+func (m *mockSource) Stop() error {
+	if m.stopBlock != nil {
+		<-m.stopBlock
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stopped = true
+	return m.stopErr
+}
+
+func (m *mockSource) Connector() *common.Connector {
+	return &common.Connector{Kind: "mock"}
+}
+
+func (m *mockSource) isStopped() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.stopped
+}
+
+// mockTarget fails the requests with a fail metadata key and reports checkErr on health checks
+type mockTarget struct {
+	checkErr error
+}
+
+func (m *mockTarget) Init(ctx context.Context, cfg config.Spec, log *logger.Logger) error {
+	return nil
+}
+
+func (m *mockTarget) Do(ctx context.Context, request *types.Request) (*types.Response, error) {
+	if request.Metadata["fail"] == "true" {
+		return nil, fmt.Errorf("request failed")
+	}
+	return types.NewResponse().SetData(request.Data), nil
+}
+
+func (m *mockTarget) Check(ctx context.Context) error {
+	return m.checkErr
+}
+
+func (m *mockTarget) Stop() error {
+	return nil
+}
+
+func (m *mockTarget) Connector() *common.Connector {
+	return &common.Connector{Kind: "mock"}
+}
+
+var (
+	testExporterOnce sync.Once
+	testExporter     *metrics.Exporter
+	testExporterErr  error
+)
+
+// newTestService returns a started service without bindings, its config is saved to a temporary file
+func newTestService(t *testing.T) *Service {
+	// the metrics collectors are registered once per process
+	testExporterOnce.Do(func() {
+		testExporter, testExporterErr = metrics.NewExporter()
+	})
+	require.NoError(t, testExporterErr)
+	viper.SetConfigFile(filepath.Join(t.TempDir(), "config.yaml"))
+	s := &Service{
+		log:      logger.NewLogger("binding-service"),
+		exporter: testExporter,
+		retries:  map[string]chan struct{}{},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	require.NoError(t, s.Start(ctx, &config.Config{}))
+	return s
+}
+
+// newTestBinder returns a binder of the mock source and target, initialized from the binding properties
+func newTestBinder(t *testing.T, cfg config.BindingConfig, source *mockSource, target *mockTarget) *Binder {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	logMd, err := middleware.NewLogMiddleware(cfg.Name, cfg.Properties)
+	require.NoError(t, err)
+	b := NewBinder()
+	b.name = cfg.Name
+	b.log = logMd.Logger
+	b.logMd = logMd
+	b.cancel = cancel
+	b.drainTimeout = time.Second
+	b.source = source
+	b.target = target
+	b.targets = []targets.Target{target}
+	b.health, err = newHealthChecker(cfg)
+	require.NoError(t, err)
+	b.health.add("mock", target)
+	b.journal, err = middleware.NewJournalMiddleware(cfg, logMd)
+	require.NoError(t, err)
+	b.dl, err = middleware.NewDeadLetterMiddleware(ctx, cfg, b.log)
+	require.NoError(t, err)
+	b.md = middleware.Chain(target, middleware.Journal(b.journal), b.track)
+	return b
+}
+
+// addTestBinding adds cfg to the service config, running binder when given
+func addTestBinding(s *Service, cfg config.BindingConfig, binder *Binder) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.setBindings(append(s.cfg.Bindings, cfg))
+	status := newStatus(cfg)
+	if binder != nil {
+		s.bindings.Store(cfg.Name, binder)
+		status = status.running()
+	}
+	s.bindingStatus.Store(cfg.Name, status)
+}
+
+func newTestBindingConfig(name string, paused bool) config.BindingConfig {
+	return config.BindingConfig{
+		Name: name,
+		Source: config.Spec{
+			Name: name,
+			Kind: "kubemq.events",
+			Properties: map[string]string{
+				"address": "localhost:1",
+				"channel": "events",
+			},
+		},
+		Target: config.Spec{
+			Name:       name,
+			Kind:       "echo",
+			Properties: map[string]string{},
+		},
+		Properties: map[string]string{
+			"init_retry_max_attempts": "1",
+		},
+		Paused: paused,
+	}
+}
+
+func statusOf(s *Service, name string) *Status {
+	for _, status := range s.GetStatus() {
+		if status.Binding == name {
+			return status
+		}
+	}
+	return nil
+}
+
+func TestService_Reload(t *testing.T) {
+	s := newTestService(t)
+	unchanged := newTestBindingConfig("unchanged", false)
+	unchangedSource := &mockSource{}
+	unchangedBinder := newTestBinder(t, unchanged, unchangedSource, &mockTarget{})
+	addTestBinding(s, unchanged, unchangedBinder)
+
+	changed := newTestBindingConfig("changed", false)
+	changedSource := &mockSource{}
+	addTestBinding(s, changed, newTestBinder(t, changed, changedSource, &mockTarget{}))
+
+	failedStop := newTestBindingConfig("failed-stop", false)
+	failedStopSource := &mockSource{stopErr: fmt.Errorf("source stop error")}
+	addTestBinding(s, failedStop, newTestBinder(t, failedStop, failedStopSource, &mockTarget{}))
+
+	removed := newTestBindingConfig("removed", false)
+	removedSource := &mockSource{}
+	addTestBinding(s, removed, newTestBinder(t, removed, removedSource, &mockTarget{}))
+
+	// the changed and added bindings are paused so the reload does not initialize real connectors
+	newChanged := newTestBindingConfig("changed", true)
+	newFailedStop := newTestBindingConfig("failed-stop", true)
+	added := newTestBindingConfig("added", true)
+	s.Reload(&config.Config{
+		Bindings: []config.BindingConfig{unchanged, newChanged, newFailedStop, added},
+	})
+
+	tests := []struct {
+		name        string
+		binding     string
+		source      *mockSource
+		wantRunning bool
+		wantStopped bool
+		wantState   string
+		wantError   string
+		wantRemoved bool
+	}{
+		{
+			name:        "unchanged binding keeps running",
+			binding:     "unchanged",
+			source:      unchangedSource,
+			wantRunning: true,
+			wantState:   StateRunning,
+		},
+		{
+			name:        "changed binding is stopped and started with the new config",
+			binding:     "changed",
+			source:      changedSource,
+			wantStopped: true,
+			wantState:   StatePaused,
+		},
+		{
+			name:        "binding failed to stop is replaced and records the stop error",
+			binding:     "failed-stop",
+			source:      failedStopSource,
+			wantStopped: true,
+			wantState:   StatePaused,
+			wantError:   "source stop error",
+		},
+		{
+			name:        "removed binding is stopped and its status deleted",
+			binding:     "removed",
+			source:      removedSource,
+			wantStopped: true,
+			wantRemoved: true,
+		},
+		{
+			name:      "added binding is started",
+			binding:   "added",
+			wantState: StatePaused,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, running := s.bindings.Load(tt.binding)
+			require.Equal(t, tt.wantRunning, running)
+			if tt.source != nil {
+				require.Equal(t, tt.wantStopped, tt.source.isStopped())
+			}
+			status := statusOf(s, tt.binding)
+			if tt.wantRemoved {
+				require.Nil(t, status)
+				_, ok := s.bindingStatus.Load(tt.binding)
+				require.False(t, ok)
+				return
+			}
+			require.NotNil(t, status)
+			require.Equal(t, tt.wantState, status.State)
+			require.Contains(t, status.LastError, tt.wantError)
+		})
+	}
+	val, ok := s.bindings.Load("unchanged")
+	require.True(t, ok)
+	require.Same(t, unchangedBinder, val.(*Binder))
+	_, ok = s.stopping.Load("failed-stop")
+	require.False(t, ok)
+}
+
+func TestService_PauseResume(t *testing.T) {
+	tests := []struct {
+		name       string
+		paused     bool
+		running    bool
+		stopErr    error
+		action     func(s *Service, name string) error
+		wantErr    bool
+		wantErrIs  error
+		wantPaused bool
+		wantState  string
+		wantError  string
+	}{
+		{
+			name:       "pause running binding",
+			running:    true,
+			action:     (*Service).PauseBinding,
+			wantPaused: true,
+			wantState:  StatePaused,
+		},
+		{
+			name:       "pause binding failed to stop",
+			running:    true,
+			stopErr:    fmt.Errorf("source stop error"),
+			action:     (*Service).PauseBinding,
+			wantPaused: true,
+			wantState:  StatePaused,
+			wantError:  "source stop error",
+		},
+		{
+			name:       "pause paused binding",
+			paused:     true,
+			action:     (*Service).PauseBinding,
+			wantPaused: true,
+			wantState:  StatePaused,
+		},
+		{
+			name:      "resume running binding",
+			running:   true,
+			action:    (*Service).ResumeBinding,
+			wantState: StateRunning,
+		},
+		{
+			name:       "resume binding failed to initialize",
+			paused:     true,
+			action:     (*Service).ResumeBinding,
+			wantErr:    true,
+			wantPaused: true,
+			wantState:  StatePaused,
+		},
+		{
+			name:      "retry running binding",
+			running:   true,
+			action:    (*Service).RetryBinding,
+			wantErr:   true,
+			wantErrIs: ErrBindingRunning,
+			wantState: StateRunning,
+		},
+		{
+			name:       "retry paused binding",
+			paused:     true,
+			action:     (*Service).RetryBinding,
+			wantErr:    true,
+			wantErrIs:  ErrBindingPaused,
+			wantPaused: true,
+			wantState:  StatePaused,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(t)
+			cfg := newTestBindingConfig("binding", tt.paused)
+			source := &mockSource{stopErr: tt.stopErr}
+			var binder *Binder
+			if tt.running {
+				binder = newTestBinder(t, cfg, source, &mockTarget{})
+			}
+			addTestBinding(s, cfg, binder)
+			err := tt.action(s, cfg.Name)
+			if !tt.wantErr {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+			if tt.wantErrIs != nil {
+				require.ErrorIs(t, err, tt.wantErrIs)
+			}
+			require.Equal(t, tt.wantPaused, s.cfg.Bindings[0].Paused)
+			_, running := s.bindings.Load(cfg.Name)
+			require.Equal(t, tt.wantState == StateRunning, running)
+			if tt.running && !running {
+				require.True(t, source.isStopped())
+			}
+			status := statusOf(s, cfg.Name)
+			require.NotNil(t, status)
+			require.Equal(t, tt.wantState, status.State)
+			require.Contains(t, status.LastError, tt.wantError)
+		})
+	}
+}
+
+func TestService_NotFound(t *testing.T) {
+	s := newTestService(t)
+	tests := []struct {
+		name   string
+		action func(s *Service, name string) error
+	}{
+		{
+			name:   "pause",
+			action: (*Service).PauseBinding,
+		},
+		{
+			name:   "resume",
+			action: (*Service).ResumeBinding,
+		},
+		{
+			name:   "retry",
+			action: (*Service).RetryBinding,
+		},
+		{
+			name:   "delete",
+			action: (*Service).DeleteBinding,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.ErrorIs(t, tt.action(s, "missing"), ErrBindingNotFound)
+		})
+	}
+}
+
+func TestService_DrainUnlocked(t *testing.T) {
+	tests := []struct {
+		name      string
+		action    func(s *Service, name string) error
+		other     func(s *Service, name string) error
+		otherName string
+		wantErrIs error
+	}{
+		{
+			name:      "other binding paused while draining",
+			action:    (*Service).PauseBinding,
+			other:     (*Service).PauseBinding,
+			otherName: "other",
+		},
+		{
+			name:      "binding deleted while pausing",
+			action:    (*Service).PauseBinding,
+			other:     (*Service).DeleteBinding,
+			otherName: "draining",
+			wantErrIs: ErrBindingNotFound,
+		},
+		{
+			name:   "binding updated while deleting",
+			action: (*Service).DeleteBinding,
+			other: func(s *Service, name string) error {
+				return s.UpdateBinding(name, newTestBindingConfig(name, true))
+			},
+			otherName: "draining",
+			wantErrIs: ErrBindingChanged,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(t)
+			draining := newTestBindingConfig("draining", false)
+			block := make(chan struct{})
+			addTestBinding(s, draining, newTestBinder(t, draining, &mockSource{stopBlock: block}, &mockTarget{}))
+			other := newTestBindingConfig("other", false)
+			addTestBinding(s, other, newTestBinder(t, other, &mockSource{}, &mockTarget{}))
+
+			errCh := make(chan error, 1)
+			go func() {
+				errCh <- tt.action(s, draining.Name)
+			}()
+			require.Eventually(t, func() bool {
+				_, ok := s.stopping.Load(draining.Name)
+				return ok
+			}, time.Second, time.Millisecond)
+			require.NoError(t, tt.other(s, tt.otherName))
+			close(block)
+			err := <-errCh
+			if tt.wantErrIs != nil {
+				require.ErrorIs(t, err, tt.wantErrIs)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
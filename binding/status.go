@@ -2,18 +2,42 @@ package binding
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/kubemq-io/kubemq-targets/config"
+	"github.com/kubemq-io/kubemq-targets/pkg/redact"
+)
+
+const (
+	StateInitializing = "initializing"
+	StateRunning      = "running"
+	StateDegraded     = "degraded"
+	StatePaused       = "paused"
+	StateStopping     = "stopping"
+	StateFailed       = "failed"
 )
 
 type Status struct {
-	Binding          string            `json:"binding"`
-	Ready            bool              `json:"ready"`
-	SourceType       string            `json:"source_type"`
-	SourceConnection string            `json:"source_connection"`
-	SourceConfig     map[string]string `json:"source_config"`
-	TargetType       string            `json:"target_type"`
-	TargetConfig     map[string]string `json:"target_config"`
+	Binding          string                       `json:"binding"`
+	Ready            bool                         `json:"ready"`
+	SourceType       string                       `json:"source_type"`
+	SourceConnection string                       `json:"source_connection"`
+	SourceConfig     map[string]string            `json:"source_config"`
+	TargetType       string                       `json:"target_type"`
+	TargetConfig     map[string]string            `json:"target_config"`
+	TargetsConfig    map[string]map[string]string `json:"targets_config,omitempty"`
+	CircuitBreaker   string                       `json:"circuit_breaker,omitempty"`
+	Paused           bool                         `json:"paused,omitempty"`
+	Draining         bool                         `json:"draining,omitempty"`
+	InFlight         int64                        `json:"in_flight"`
+	Health           *Health                      `json:"health,omitempty"`
+	State            string                       `json:"state"`
+	InitAttempts     int                          `json:"init_attempts"`
+	LastError        string                       `json:"last_error,omitempty"`
+	LastFailureAt    *time.Time                   `json:"last_failure_at,omitempty"`
+	LastSuccessAt    *time.Time                   `json:"last_success_at,omitempty"`
+	StartedAt        *time.Time                   `json:"started_at,omitempty"`
+	Uptime           string                       `json:"uptime,omitempty"`
 }
 
 func getSourceConnection(properties map[string]string) string {
@@ -21,13 +45,49 @@ func getSourceConnection(properties map[string]string) string {
 }
 
 func newStatus(cfg config.BindingConfig) *Status {
-	return &Status{
+	status := &Status{
 		Binding:          cfg.Name,
 		Ready:            false,
 		SourceType:       cfg.Source.Kind,
 		SourceConnection: getSourceConnection(cfg.Source.Properties),
-		SourceConfig:     cfg.Source.Properties,
-		TargetType:       cfg.Target.Kind,
-		TargetConfig:     cfg.Target.Properties,
+		SourceConfig:     redactProperties(cfg.Source.Kind, cfg.Source.Properties),
+		TargetType:       cfg.TargetKind(),
+		TargetConfig:     redactProperties(cfg.Target.Kind, cfg.Target.Properties),
+		Paused:           cfg.Paused,
+		State:            StateInitializing,
+	}
+	if len(cfg.Targets) > 0 {
+		status.TargetsConfig = map[string]map[string]string{}
+		for _, target := range cfg.Targets {
+			status.TargetsConfig[target.Name] = redactProperties(target.Kind, target.Properties)
+		}
 	}
+	if cfg.Paused {
+		status.State = StatePaused
+	}
+	return status
+}
+
+// failed returns a copy of the status recording err as the last failure, with the url credentials masked
+func (s Status) failed(err error) *Status {
+	now := time.Now()
+	s.LastError = redact.Text(err.Error())
+	s.LastFailureAt = &now
+	return &s
+}
+
+// running returns a copy of the status of a binding started now
+func (s Status) running() *Status {
+	now := time.Now()
+	s.Ready = true
+	s.State = StateRunning
+	s.StartedAt = &now
+	return &s
+}
+
+// stopped returns a copy of the status of a binding which failed all its initialization attempts
+func (s Status) stopped() *Status {
+	s.Ready = false
+	s.State = StateFailed
+	return &s
 }
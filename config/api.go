@@ -0,0 +1,62 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	ApiRoleRead    = "read"
+	ApiRoleRequest = "request"
+	ApiRoleAdmin   = "admin"
+)
+
+var apiRoles = map[string]bool{
+	ApiRoleRead:    true,
+	ApiRoleRequest: true,
+	ApiRoleAdmin:   true,
+}
+
+// ApiConfig sets the api server TLS, CORS origins and the credentials of each api role,
+// ApiAuthToken of Config is the admin role bearer token
+type ApiConfig struct {
+	CertFile     string            `json:"certFile,omitempty"`
+	KeyFile      string            `json:"keyFile,omitempty"`
+	ClientCAFile string            `json:"clientCaFile,omitempty"`
+	ClientRoles  map[string]string `json:"clientRoles,omitempty"`
+	ReadToken    string            `json:"readToken,omitempty"`
+	RequestToken string            `json:"requestToken,omitempty"`
+	CorsOrigins  []string          `json:"corsOrigins,omitempty"`
+}
+
+func (a *ApiConfig) Validate() error {
+	if (a.CertFile == "") != (a.KeyFile == "") {
+		return fmt.Errorf("api cert file and key file must be set together")
+	}
+	if a.ClientCAFile != "" && a.CertFile == "" {
+		return fmt.Errorf("api client ca file requires api cert file and key file")
+	}
+	for name, role := range a.ClientRoles {
+		if !apiRoles[role] {
+			return fmt.Errorf("invalid api client %s role %s", name, role)
+		}
+	}
+	return nil
+}
+
+func (a ApiConfig) TLSEnabled() bool {
+	return a.CertFile != ""
+}
+
+func (a ApiConfig) Equal(other ApiConfig) bool {
+	left, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	right, err := json.Marshal(other)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(left, right)
+}
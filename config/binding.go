@@ -1,8 +1,12 @@
 package config
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"strings"
 
+	"github.com/kubemq-io/kubemq-targets/pkg/filter"
 	"github.com/kubemq-io/kubemq-targets/types"
 )
 
@@ -10,7 +14,73 @@ type BindingConfig struct {
 	Name       string         `json:"name"`
 	Source     Spec           `json:"source"`
 	Target     Spec           `json:"target"`
+	Targets    []TargetConfig `json:"targets,omitempty"`
+	Strategy   string         `json:"strategy,omitempty"`
 	Properties types.Metadata `json:"properties"`
+	Paused     bool           `json:"paused,omitempty"`
+}
+
+type TargetConfig struct {
+	Name       string         `json:"name"`
+	Kind       string         `json:"kind"`
+	Filter     string         `json:"filter,omitempty"`
+	Properties types.Metadata `json:"properties"`
+	Middleware types.Metadata `json:"middleware,omitempty"`
+}
+
+var strategies = map[string]bool{
+	"":            true,
+	"broadcast":   true,
+	"failover":    true,
+	"round-robin": true,
+}
+
+func (t TargetConfig) Spec() Spec {
+	return Spec{
+		Name:       t.Name,
+		Kind:       t.Kind,
+		Properties: t.Properties,
+	}
+}
+
+func (t TargetConfig) Validate() error {
+	if t.Name == "" {
+		return fmt.Errorf("target must have name")
+	}
+	if err := t.Spec().Validate(); err != nil {
+		return err
+	}
+	if t.Filter != "" {
+		if _, err := filter.Compile(t.Filter); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TargetKind returns the target kind, or the comma separated kinds of all routed targets
+func (b BindingConfig) TargetKind() string {
+	if len(b.Targets) == 0 {
+		return b.Target.Kind
+	}
+	var kinds []string
+	for _, target := range b.Targets {
+		kinds = append(kinds, target.Kind)
+	}
+	return strings.Join(kinds, ",")
+}
+
+// Equal reports whether b and other configure the same binding
+func (b BindingConfig) Equal(other BindingConfig) bool {
+	left, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+	right, err := json.Marshal(other)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(left, right)
 }
 
 func (b BindingConfig) Validate() error {
@@ -20,8 +90,32 @@ func (b BindingConfig) Validate() error {
 	if err := b.Source.Validate(); err != nil {
 		return fmt.Errorf("binding source error, %w", err)
 	}
-	if err := b.Target.Validate(); err != nil {
-		return fmt.Errorf("binding target error, %w", err)
+	if len(b.Targets) == 0 {
+		if err := b.Target.Validate(); err != nil {
+			return fmt.Errorf("binding target error, %w", err)
+		}
+	} else {
+		if b.Target.Kind != "" {
+			return fmt.Errorf("binding can have either target or targets")
+		}
+		if !strategies[b.Strategy] {
+			return fmt.Errorf("invalid binding targets strategy %s", b.Strategy)
+		}
+		names := map[string]bool{}
+		for _, target := range b.Targets {
+			if err := target.Validate(); err != nil {
+				return fmt.Errorf("binding target %s error, %w", target.Name, err)
+			}
+			if names[target.Name] {
+				return fmt.Errorf("duplicated binding target names found: %s", target.Name)
+			}
+			names[target.Name] = true
+		}
+	}
+	if expr := b.Properties.ParseString("filter", ""); expr != "" {
+		if _, err := filter.Compile(expr); err != nil {
+			return fmt.Errorf("binding filter error, %w", err)
+		}
 	}
 	return nil
 }
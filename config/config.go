@@ -11,6 +11,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/fsnotify/fsnotify"
 	"github.com/ghodss/yaml"
@@ -25,12 +26,17 @@ var (
 	configFile string
 	logr       = logger.NewLogger("config")
 	lastConf   *Config
+	lastConfMu sync.Mutex
 )
 
 type Config struct {
-	Bindings []BindingConfig `json:"bindings"`
-	ApiPort  int             `json:"apiPort"`
-	LogLevel string          `json:"logLevel"`
+	Bindings     []BindingConfig `json:"bindings"`
+	ApiPort      int             `json:"apiPort"`
+	ApiAuthToken string          `json:"apiAuthToken,omitempty"`
+	Api          ApiConfig       `json:"api"`
+	LogLevel     string          `json:"logLevel"`
+	Tracing      TracingConfig   `json:"tracing"`
+	Secrets      SecretsConfig   `json:"secrets"`
 }
 
 func SetConfigFile(filename string) {
@@ -59,6 +65,15 @@ func (c *Config) Validate() error {
 	if c.ApiPort == 0 {
 		c.ApiPort = defaultApiPort
 	}
+	if err := c.Api.Validate(); err != nil {
+		return err
+	}
+	if err := c.Tracing.Validate(); err != nil {
+		return err
+	}
+	if err := c.Secrets.Validate(); err != nil {
+		return err
+	}
 	exitedBindings := map[string]string{}
 	for _, binding := range c.Bindings {
 		if err := binding.Validate(); err != nil {
@@ -190,11 +205,43 @@ func Load(cfgCh chan *Config) (*Config, error) {
 			logr.Errorf("error loading new configuration file: %s", err.Error())
 			return
 		}
-		if cfg.hash() != lastConf.hash() {
-			logr.Info("config file changed, reloading...")
+		lastConfMu.Lock()
+		changed := cfg.hash() != lastConf.hash()
+		if changed {
 			lastConf = cfg.copy()
+		}
+		lastConfMu.Unlock()
+		if changed {
+			logr.Info("config file changed, reloading...")
 			cfgCh <- cfg
 		}
 	})
 	return cfg, err
 }
+
+// Save writes cfg back to the loaded config file, in the format of the file.
+// The written configuration does not trigger a reload of the watched config file.
+func Save(cfg *Config) error {
+	filename := viper.ConfigFileUsed()
+	if filename == "" {
+		return fmt.Errorf("no config file loaded")
+	}
+	var data []byte
+	var err error
+	if strings.HasSuffix(filename, ".json") {
+		data, err = json.MarshalIndent(cfg, "", "  ")
+	} else {
+		data, err = yaml.Marshal(cfg)
+	}
+	if err != nil {
+		return err
+	}
+	lastConfMu.Lock()
+	defer lastConfMu.Unlock()
+	/* #nosec */
+	if err := ioutil.WriteFile(filename, data, 0o644); err != nil {
+		return fmt.Errorf("error saving config file, %w", err)
+	}
+	lastConf = cfg.copy()
+	return nil
+}
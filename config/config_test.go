@@ -2,8 +2,11 @@ package config
 
 import (
 	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
+
+	"github.com/spf13/viper"
 )
 
 func TestConfig_Validate(t *testing.T) {
@@ -173,3 +176,275 @@ bindings:
 		})
 	}
 }
+
+func TestBindingConfig_Validate_Targets(t *testing.T) {
+	source := Spec{Kind: "source-1"}
+	tests := []struct {
+		name    string
+		binding BindingConfig
+		wantErr bool
+	}{
+		{
+			name: "valid targets",
+			binding: BindingConfig{
+				Name:   "binding-1",
+				Source: source,
+				Targets: []TargetConfig{
+					{Name: "target-1", Kind: "target-1", Filter: "metadata.type == 'order'"},
+					{Name: "target-2", Kind: "target-2"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid targets - bad strategy",
+			binding: BindingConfig{
+				Name:     "binding-1",
+				Source:   source,
+				Targets:  []TargetConfig{{Name: "target-1", Kind: "target-1"}},
+				Strategy: "bad-strategy",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid targets - both target and targets",
+			binding: BindingConfig{
+				Name:    "binding-1",
+				Source:  source,
+				Target:  Spec{Kind: "target-1"},
+				Targets: []TargetConfig{{Name: "target-2", Kind: "target-2"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid targets - duplicated names",
+			binding: BindingConfig{
+				Name:   "binding-1",
+				Source: source,
+				Targets: []TargetConfig{
+					{Name: "target-1", Kind: "target-1"},
+					{Name: "target-1", Kind: "target-2"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid targets - no name",
+			binding: BindingConfig{
+				Name:    "binding-1",
+				Source:  source,
+				Targets: []TargetConfig{{Kind: "target-1"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid targets - bad filter",
+			binding: BindingConfig{
+				Name:    "binding-1",
+				Source:  source,
+				Targets: []TargetConfig{{Name: "target-1", Kind: "target-1", Filter: "metadata.type =="}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid binding filter",
+			binding: BindingConfig{
+				Name:       "binding-1",
+				Source:     source,
+				Target:     Spec{Kind: "target-1"},
+				Properties: map[string]string{"filter": "(metadata.type"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.binding.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTracingConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     TracingConfig
+		want    TracingConfig
+		wantErr bool
+	}{
+		{
+			name: "disabled",
+			cfg:  TracingConfig{},
+			want: TracingConfig{},
+		},
+		{
+			name: "enabled with defaults",
+			cfg: TracingConfig{
+				Enabled:  true,
+				Endpoint: "localhost:4318",
+			},
+			want: TracingConfig{
+				Enabled:     true,
+				Endpoint:    "localhost:4318",
+				ServiceName: "kubemq-targets",
+				SampleRatio: 1,
+			},
+		},
+		{
+			name: "invalid - no endpoint",
+			cfg: TracingConfig{
+				Enabled: true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid - bad sample ratio",
+			cfg: TracingConfig{
+				Enabled:     true,
+				Endpoint:    "localhost:4318",
+				SampleRatio: 1.5,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(tt.cfg, tt.want) {
+				t.Errorf("Validate() got = %v, want %v", tt.cfg, tt.want)
+			}
+		})
+	}
+}
+
+func TestSave(t *testing.T) {
+	cfg := &Config{
+		Bindings: []BindingConfig{
+			{
+				Name: "binding-1",
+				Source: Spec{
+					Name:       "source-1",
+					Kind:       "source-1",
+					Properties: map[string]string{"channel": "some-channel"},
+				},
+				Target: Spec{
+					Name:       "target-1",
+					Kind:       "target-1",
+					Properties: map[string]string{"url": "http://localhost"},
+				},
+				Paused: true,
+			},
+		},
+		ApiPort: 8080,
+	}
+	for _, ext := range []string{"yaml", "json"} {
+		t.Run(ext, func(t *testing.T) {
+			viper.Reset()
+			viper.SetConfigFile(filepath.Join(t.TempDir(), "config."+ext))
+			if err := Save(cfg); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+			if err := viper.ReadInConfig(); err != nil {
+				t.Fatalf("ReadInConfig() error = %v", err)
+			}
+			got := &Config{}
+			if err := viper.Unmarshal(got); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if got.hash() != cfg.hash() {
+				t.Errorf("Save() got = %v, want %v", got, cfg)
+			}
+			if lastConf.hash() != cfg.hash() {
+				t.Errorf("Save() last config was not updated")
+			}
+		})
+	}
+	viper.Reset()
+	if err := Save(cfg); err == nil {
+		t.Errorf("Save() with no config file loaded, expected error")
+	}
+}
+
+func TestBindingConfig_Equal(t *testing.T) {
+	binding := BindingConfig{
+		Name: "binding-1",
+		Source: Spec{
+			Name:       "source-1",
+			Kind:       "source-1",
+			Properties: map[string]string{"channel": "some-channel"},
+		},
+		Target: Spec{
+			Name:       "target-1",
+			Kind:       "target-1",
+			Properties: map[string]string{"url": "http://localhost"},
+		},
+	}
+	same := binding
+	same.Source.Properties = map[string]string{"channel": "some-channel"}
+	changed := binding
+	changed.Target.Properties = map[string]string{"url": "http://remotehost"}
+	paused := binding
+	paused.Paused = true
+
+	if !binding.Equal(same) {
+		t.Errorf("Equal() expected equal bindings")
+	}
+	if binding.Equal(changed) {
+		t.Errorf("Equal() expected changed target properties")
+	}
+	if binding.Equal(paused) {
+		t.Errorf("Equal() expected changed paused state")
+	}
+}
+
+func TestApiConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     ApiConfig
+		wantErr bool
+	}{
+		{
+			name: "no tls",
+			cfg:  ApiConfig{ReadToken: "read-token"},
+		},
+		{
+			name: "mtls",
+			cfg: ApiConfig{
+				CertFile:     "tls.crt",
+				KeyFile:      "tls.key",
+				ClientCAFile: "ca.crt",
+				ClientRoles:  map[string]string{"client": ApiRoleRequest},
+			},
+		},
+		{
+			name:    "invalid - cert without key",
+			cfg:     ApiConfig{CertFile: "tls.crt"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid - client ca without tls",
+			cfg:     ApiConfig{ClientCAFile: "ca.crt"},
+			wantErr: true,
+		},
+		{
+			name: "invalid - bad client role",
+			cfg: ApiConfig{
+				CertFile:    "tls.crt",
+				KeyFile:     "tls.key",
+				ClientRoles: map[string]string{"client": "bad-role"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/kubemq-io/kubemq-targets/types"
+)
+
+const defaultKubernetesSecretsPath = "/etc/kubemq-targets/secrets"
+
+// secretReference matches ${scheme:key} references in property values
+var secretReference = regexp.MustCompile(`\$\{([a-z0-9]+):([^}]+)\}`)
+
+// SecretProvider returns the value of a secret referenced as ${scheme:key} in a property value
+type SecretProvider interface {
+	Resolve(ctx context.Context, key string) (string, error)
+}
+
+// SecretsConfig sets the secret providers and how often references are resolved again to pick up rotated secrets
+type SecretsConfig struct {
+	RefreshIntervalSeconds int    `json:"refreshIntervalSeconds,omitempty"`
+	KubernetesPath         string `json:"kubernetesPath,omitempty"`
+	VaultAddress           string `json:"vaultAddress,omitempty"`
+	VaultToken             string `json:"vaultToken,omitempty"`
+	VaultNamespace         string `json:"vaultNamespace,omitempty"`
+}
+
+func (s *SecretsConfig) Validate() error {
+	if s.RefreshIntervalSeconds < 0 {
+		return fmt.Errorf("secrets refresh interval seconds cannot be negative")
+	}
+	if s.VaultToken != "" && s.VaultAddress == "" {
+		return fmt.Errorf("secrets vault address cannot be empty when vault token is set")
+	}
+	return nil
+}
+
+func (s SecretsConfig) RefreshInterval() time.Duration {
+	return time.Duration(s.RefreshIntervalSeconds) * time.Second
+}
+
+// SecretResolver replaces secret references in binding properties with the values of the registered providers
+type SecretResolver struct {
+	providers map[string]SecretProvider
+}
+
+// NewSecretResolver returns a resolver with the env, file and k8s providers, and the vault provider when an address is set
+func NewSecretResolver(ctx context.Context, cfg SecretsConfig) (*SecretResolver, error) {
+	r := &SecretResolver{
+		providers: map[string]SecretProvider{},
+	}
+	r.Register("env", envSecretProvider{})
+	r.Register("file", fileSecretProvider{})
+	kubernetesPath := cfg.KubernetesPath
+	if kubernetesPath == "" {
+		kubernetesPath = defaultKubernetesSecretsPath
+	}
+	r.Register("k8s", kubernetesSecretProvider{path: kubernetesPath})
+	if cfg.VaultAddress != "" {
+		token, err := r.resolveValue(ctx, cfg.VaultToken)
+		if err != nil {
+			return nil, fmt.Errorf("error resolving vault token, %w", err)
+		}
+		r.Register("vault", newVaultSecretProvider(cfg.VaultAddress, token, cfg.VaultNamespace))
+	}
+	return r, nil
+}
+
+// Register adds or replaces the provider of scheme
+func (r *SecretResolver) Register(scheme string, provider SecretProvider) {
+	r.providers[scheme] = provider
+}
+
+// resolveValue replaces the references of registered schemes in value, references of other schemes are kept as is
+func (r *SecretResolver) resolveValue(ctx context.Context, value string) (string, error) {
+	if !strings.Contains(value, "${") {
+		return value, nil
+	}
+	var resolveErr error
+	resolved := secretReference.ReplaceAllStringFunc(value, func(ref string) string {
+		match := secretReference.FindStringSubmatch(ref)
+		provider, ok := r.providers[match[1]]
+		if !ok || resolveErr != nil {
+			return ref
+		}
+		secret, err := provider.Resolve(ctx, match[2])
+		if err != nil {
+			resolveErr = fmt.Errorf("secret %s, %w", ref, err)
+			return ref
+		}
+		return secret
+	})
+	if resolveErr != nil {
+		return "", resolveErr
+	}
+	return resolved, nil
+}
+
+func (r *SecretResolver) resolveMetadata(ctx context.Context, meta types.Metadata) (types.Metadata, error) {
+	if meta == nil {
+		return nil, nil
+	}
+	resolved := types.NewMetadata()
+	for key, value := range meta {
+		resolvedValue, err := r.resolveValue(ctx, value)
+		if err != nil {
+			return nil, fmt.Errorf("property %s, %w", key, err)
+		}
+		resolved[key] = resolvedValue
+	}
+	return resolved, nil
+}
+
+// ResolveBinding returns a copy of cfg with the secret references of all its properties resolved
+func (r *SecretResolver) ResolveBinding(ctx context.Context, cfg BindingConfig) (BindingConfig, error) {
+	var err error
+	resolved := cfg
+	if resolved.Properties, err = r.resolveMetadata(ctx, cfg.Properties); err != nil {
+		return cfg, fmt.Errorf("binding %s, %w", cfg.Name, err)
+	}
+	if resolved.Source.Properties, err = r.resolveMetadata(ctx, cfg.Source.Properties); err != nil {
+		return cfg, fmt.Errorf("binding %s source, %w", cfg.Name, err)
+	}
+	if resolved.Target.Properties, err = r.resolveMetadata(ctx, cfg.Target.Properties); err != nil {
+		return cfg, fmt.Errorf("binding %s target, %w", cfg.Name, err)
+	}
+	if cfg.Targets != nil {
+		resolved.Targets = make([]TargetConfig, len(cfg.Targets))
+		for i, target := range cfg.Targets {
+			resolved.Targets[i] = target
+			if resolved.Targets[i].Properties, err = r.resolveMetadata(ctx, target.Properties); err != nil {
+				return cfg, fmt.Errorf("binding %s target %s, %w", cfg.Name, target.Name, err)
+			}
+			if resolved.Targets[i].Middleware, err = r.resolveMetadata(ctx, target.Middleware); err != nil {
+				return cfg, fmt.Errorf("binding %s target %s middleware, %w", cfg.Name, target.Name, err)
+			}
+		}
+	}
+	return resolved, nil
+}
+
+// envSecretProvider resolves ${env:NAME} from the environment variable NAME
+type envSecretProvider struct{}
+
+func (envSecretProvider) Resolve(_ context.Context, key string) (string, error) {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s not found", key)
+	}
+	return value, nil
+}
+
+// fileSecretProvider resolves ${file:/path} from the content of the file, without trailing new lines
+type fileSecretProvider struct{}
+
+func (fileSecretProvider) Resolve(_ context.Context, key string) (string, error) {
+	data, err := ioutil.ReadFile(filepath.Clean(key))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// kubernetesSecretProvider resolves ${k8s:secret/key} from a secret volume mounted under path/secret
+type kubernetesSecretProvider struct {
+	path string
+}
+
+func (k kubernetesSecretProvider) Resolve(ctx context.Context, key string) (string, error) {
+	parts := strings.Split(key, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", fmt.Errorf("invalid kubernetes secret reference %s, expected secret/key", key)
+	}
+	return fileSecretProvider{}.Resolve(ctx, filepath.Join(k.path, parts[0], parts[1]))
+}
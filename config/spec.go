@@ -7,7 +7,7 @@ import (
 )
 
 type Spec struct {
-	Name       string         `json:"-"`
+	Name       string         `json:"name,omitempty"`
 	Kind       string         `json:"kind"`
 	Properties types.Metadata `json:"properties"`
 }
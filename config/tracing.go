@@ -0,0 +1,35 @@
+package config
+
+import "fmt"
+
+const defaultTracingServiceName = "kubemq-targets"
+
+// TracingConfig sets the OpenTelemetry OTLP/HTTP exporter, tracing is disabled unless enabled is set
+type TracingConfig struct {
+	Enabled     bool              `json:"enabled"`
+	Endpoint    string            `json:"endpoint"`
+	UrlPath     string            `json:"urlPath,omitempty"`
+	Insecure    bool              `json:"insecure,omitempty"`
+	Headers     map[string]string `json:"headers,omitempty"`
+	ServiceName string            `json:"serviceName,omitempty"`
+	SampleRatio float64           `json:"sampleRatio,omitempty"`
+}
+
+func (t *TracingConfig) Validate() error {
+	if !t.Enabled {
+		return nil
+	}
+	if t.Endpoint == "" {
+		return fmt.Errorf("tracing endpoint cannot be empty")
+	}
+	if t.SampleRatio < 0 || t.SampleRatio > 1 {
+		return fmt.Errorf("tracing sample ratio must be between 0 and 1")
+	}
+	if t.SampleRatio == 0 {
+		t.SampleRatio = 1
+	}
+	if t.ServiceName == "" {
+		t.ServiceName = defaultTracingServiceName
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const vaultRequestTimeout = 10 * time.Second
+
+// vaultSecretProvider resolves ${vault:path#field} from a Vault compatible KV secrets engine, v1 or v2
+type vaultSecretProvider struct {
+	address   string
+	token     string
+	namespace string
+	client    *http.Client
+}
+
+func newVaultSecretProvider(address, token, namespace string) *vaultSecretProvider {
+	return &vaultSecretProvider{
+		address:   strings.TrimSuffix(address, "/"),
+		token:     token,
+		namespace: namespace,
+		client:    &http.Client{Timeout: vaultRequestTimeout},
+	}
+}
+
+func (v *vaultSecretProvider) Resolve(ctx context.Context, key string) (string, error) {
+	parts := strings.SplitN(key, "#", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", fmt.Errorf("invalid vault secret reference %s, expected path#field", key)
+	}
+	path, field := strings.TrimPrefix(parts[0], "/"), parts[1]
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/v1/%s", v.address, path), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", v.token)
+	if v.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", v.namespace)
+	}
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("vault request failed, %s", resp.Status)
+	}
+	body := struct {
+		Data map[string]interface{} `json:"data"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("invalid vault response, %w", err)
+	}
+	data := body.Data
+	// kv v2 nests the secret fields under data.data
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		if _, ok := data[field]; !ok {
+			data = nested
+		}
+	}
+	value, ok := data[field]
+	if !ok {
+		return "", fmt.Errorf("field %s not found in vault secret %s", field, path)
+	}
+	if str, ok := value.(string); ok {
+		return str, nil
+	}
+	return fmt.Sprint(value), nil
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/kubemq-io/kubemq-targets/pkg/browser"
 	"github.com/kubemq-io/kubemq-targets/pkg/builder"
 	"github.com/kubemq-io/kubemq-targets/pkg/logger"
+	"github.com/kubemq-io/kubemq-targets/pkg/tracing"
 	"github.com/kubemq-io/kubemq-targets/sources"
 	"github.com/kubemq-io/kubemq-targets/targets"
 )
@@ -91,6 +92,13 @@ func runInteractive(serviceExit chan bool) error {
 	}
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
+	tracer, err := tracing.NewProvider(ctx, cfg.Tracing)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tracer.Shutdown(context.Background())
+	}()
 	bindingsService, err := binding.New()
 	if err != nil {
 		return err
@@ -100,7 +108,7 @@ func runInteractive(serviceExit chan bool) error {
 		return err
 	}
 
-	apiServer, err := api.Start(ctx, cfg.ApiPort, bindingsService)
+	apiServer, err := api.Start(ctx, cfg, bindingsService)
 	if err != nil {
 		return err
 	}
@@ -111,10 +119,11 @@ func runInteractive(serviceExit chan bool) error {
 			if err != nil {
 				return fmt.Errorf("error on validation new config file: %s", err.Error())
 			}
-			bindingsService.Stop()
-			err = bindingsService.Start(ctx, newConfig)
-			if err != nil {
-				return fmt.Errorf("error on restarting service with new config file: %s", err.Error())
+			bindingsService.Reload(newConfig)
+			apiChanged := newConfig.ApiPort != cfg.ApiPort || newConfig.ApiAuthToken != cfg.ApiAuthToken || !newConfig.Api.Equal(cfg.Api)
+			cfg = newConfig
+			if !apiChanged {
+				continue
 			}
 			if apiServer != nil {
 				err = apiServer.Stop()
@@ -123,7 +132,7 @@ func runInteractive(serviceExit chan bool) error {
 				}
 			}
 
-			apiServer, err = api.Start(ctx, newConfig.ApiPort, bindingsService)
+			apiServer, err = api.Start(ctx, newConfig, bindingsService)
 			if err != nil {
 				return fmt.Errorf("error on start api server: %s", err.Error())
 			}
@@ -15,6 +15,7 @@ import (
 	"github.com/kubemq-io/kubemq-targets/binding"
 	"github.com/kubemq-io/kubemq-targets/config"
 	"github.com/kubemq-io/kubemq-targets/pkg/logger"
+	"github.com/kubemq-io/kubemq-targets/pkg/tracing"
 )
 
 var version = ""
@@ -40,6 +41,13 @@ func run() error {
 	}
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
+	tracer, err := tracing.NewProvider(ctx, cfg.Tracing)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tracer.Shutdown(context.Background())
+	}()
 	bindingsService, err := binding.New()
 	if err != nil {
 		return err
@@ -49,7 +57,7 @@ func run() error {
 		return err
 	}
 
-	apiServer, err := api.Start(ctx, cfg.ApiPort, bindingsService)
+	apiServer, err := api.Start(ctx, cfg, bindingsService)
 	if err != nil {
 		return err
 	}
@@ -60,10 +68,11 @@ func run() error {
 			if err != nil {
 				return fmt.Errorf("error on validation new config file: %s", err.Error())
 			}
-			bindingsService.Stop()
-			err = bindingsService.Start(ctx, newConfig)
-			if err != nil {
-				return fmt.Errorf("error on restarting service with new config file: %s", err.Error())
+			bindingsService.Reload(newConfig)
+			apiChanged := newConfig.ApiPort != cfg.ApiPort || newConfig.ApiAuthToken != cfg.ApiAuthToken || !newConfig.Api.Equal(cfg.Api)
+			cfg = newConfig
+			if !apiChanged {
+				continue
 			}
 			if apiServer != nil {
 				err = apiServer.Stop()
@@ -72,7 +81,7 @@ func run() error {
 				}
 			}
 
-			apiServer, err = api.Start(ctx, newConfig.ApiPort, bindingsService)
+			apiServer, err = api.Start(ctx, newConfig, bindingsService)
 			if err != nil {
 				return fmt.Errorf("error on start api server: %s", err.Error())
 			}
@@ -0,0 +1,199 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/kubemq-io/kubemq-targets/types"
+)
+
+const defaultBatchMaxWait = 100
+
+var ErrBatchClosed = errors.New("batch processing is closed")
+
+type BatchFunc func(ctx context.Context, requests []*types.Request) ([]*types.Response, []error)
+
+type batchResult struct {
+	response *types.Response
+	err      error
+}
+
+type batchItem struct {
+	ctx     context.Context
+	request *types.Request
+	result  chan batchResult
+}
+
+type BatchMiddleware struct {
+	maxItems  int
+	maxBytes  int
+	maxWait   time.Duration
+	doBatch   BatchFunc
+	queue     chan *batchItem
+	ctx       context.Context
+	cancel    context.CancelFunc
+	done      chan struct{}
+	stopped   chan struct{}
+	flushNow  chan struct{}
+	wg        sync.WaitGroup
+	once      sync.Once
+	flushOnce sync.Once
+}
+
+func NewBatchMiddleware(meta types.Metadata) (*BatchMiddleware, error) {
+	maxItems, err := meta.ParseIntWithRange("batch_max_items", 0, 0, math.MaxInt32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid batch max items value, %w", err)
+	}
+	maxBytes, err := meta.ParseIntWithRange("batch_max_bytes", 0, 0, math.MaxInt32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid batch max bytes value, %w", err)
+	}
+	maxWait, err := meta.ParseIntWithRange("batch_max_wait_milliseconds", defaultBatchMaxWait, 1, math.MaxInt32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid batch max wait milliseconds value, %w", err)
+	}
+	return &BatchMiddleware{
+		maxItems: maxItems,
+		maxBytes: maxBytes,
+		maxWait:  time.Duration(maxWait) * time.Millisecond,
+	}, nil
+}
+
+// Enabled reports whether requests are batched, by max items above one, or by max bytes only when max items is not set
+func (bm *BatchMiddleware) Enabled() bool {
+	return bm.maxItems > 1 || (bm.maxItems == 0 && bm.maxBytes > 0)
+}
+
+func (bm *BatchMiddleware) Start(doBatch BatchFunc) {
+	bm.doBatch = doBatch
+	bm.queue = make(chan *batchItem)
+	bm.done = make(chan struct{})
+	bm.stopped = make(chan struct{})
+	bm.flushNow = make(chan struct{})
+	bm.ctx, bm.cancel = context.WithCancel(context.Background())
+	go bm.run()
+}
+
+func (bm *BatchMiddleware) Do(ctx context.Context, request *types.Request) (*types.Response, error) {
+	item := &batchItem{
+		ctx:     ctx,
+		request: request,
+		result:  make(chan batchResult, 1),
+	}
+	select {
+	case bm.queue <- item:
+	case <-bm.done:
+		return nil, ErrBatchClosed
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	select {
+	case res := <-item.result:
+		return res.response, res.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (bm *BatchMiddleware) run() {
+	defer close(bm.stopped)
+	var pending []*batchItem
+	size := 0
+	var timer *time.Timer
+	var timerC <-chan time.Time
+	flushNow := bm.flushNow
+	immediate := false
+	flush := func() {
+		if timer != nil {
+			timer.Stop()
+			timer, timerC = nil, nil
+		}
+		if len(pending) == 0 {
+			return
+		}
+		items := pending
+		pending, size = nil, 0
+		bm.wg.Add(1)
+		go func() {
+			defer bm.wg.Done()
+			bm.flush(items)
+		}()
+	}
+	for {
+		select {
+		case item := <-bm.queue:
+			pending = append(pending, item)
+			if item.request != nil {
+				size += len(item.request.Data)
+			}
+			if timer == nil {
+				timer = time.NewTimer(bm.maxWait)
+				timerC = timer.C
+			}
+			if immediate || (bm.maxItems > 0 && len(pending) >= bm.maxItems) || (bm.maxBytes > 0 && size >= bm.maxBytes) {
+				flush()
+			}
+		case <-timerC:
+			timer, timerC = nil, nil
+			flush()
+		case <-flushNow:
+			flushNow, immediate = nil, true
+			flush()
+		case <-bm.done:
+			flush()
+			return
+		}
+	}
+}
+
+func (bm *BatchMiddleware) flush(items []*batchItem) {
+	var active []*batchItem
+	var requests []*types.Request
+	for _, item := range items {
+		if item.ctx.Err() != nil {
+			continue
+		}
+		active = append(active, item)
+		requests = append(requests, item.request)
+	}
+	if len(active) == 0 {
+		return
+	}
+	responses, errs := bm.doBatch(bm.ctx, requests)
+	for i, item := range active {
+		res := batchResult{}
+		if len(responses) != len(active) || len(errs) != len(active) {
+			res.err = fmt.Errorf("invalid batch results, expected %d results, got %d responses and %d errors", len(active), len(responses), len(errs))
+		} else {
+			res.response, res.err = responses[i], errs[i]
+		}
+		item.result <- res
+	}
+}
+
+// Flush sends the pending requests and stops batching, every following request is sent on its own
+func (bm *BatchMiddleware) Flush() {
+	if bm.flushNow == nil {
+		return
+	}
+	bm.flushOnce.Do(func() {
+		close(bm.flushNow)
+	})
+}
+
+func (bm *BatchMiddleware) Close() {
+	if bm.done == nil {
+		return
+	}
+	bm.once.Do(func() {
+		close(bm.done)
+		<-bm.stopped
+		bm.wg.Wait()
+		bm.cancel()
+	})
+}
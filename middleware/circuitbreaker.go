@@ -0,0 +1,149 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/kubemq-io/kubemq-targets/config"
+	"github.com/kubemq-io/kubemq-targets/pkg/metrics"
+	"github.com/kubemq-io/kubemq-targets/types"
+)
+
+type CircuitBreakerState string
+
+const (
+	CircuitBreakerStateDisabled CircuitBreakerState = ""
+	CircuitBreakerStateClosed   CircuitBreakerState = "closed"
+	CircuitBreakerStateHalfOpen CircuitBreakerState = "half-open"
+	CircuitBreakerStateOpen     CircuitBreakerState = "open"
+)
+
+var ErrCircuitOpen = errors.New("circuit breaker is open, target execution rejected")
+
+var circuitBreakerStateValues = map[CircuitBreakerState]float64{
+	CircuitBreakerStateClosed:   0,
+	CircuitBreakerStateHalfOpen: 1,
+	CircuitBreakerStateOpen:     2,
+}
+
+type CircuitBreakerMiddleware struct {
+	mu               sync.Mutex
+	failureThreshold int
+	openDuration     time.Duration
+	halfOpenProbes   int
+	state            CircuitBreakerState
+	failures         int
+	probes           int
+	successes        int
+	openedAt         time.Time
+	exporter         *metrics.Exporter
+	metricReport     *metrics.Report
+}
+
+func NewCircuitBreakerMiddleware(cfg config.BindingConfig, exporter *metrics.Exporter) (*CircuitBreakerMiddleware, error) {
+	failureThreshold, err := cfg.Properties.ParseIntWithRange("circuit_breaker_failure_threshold", 0, 0, math.MaxInt32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid circuit breaker failure threshold value")
+	}
+	openDuration, err := cfg.Properties.ParseIntWithRange("circuit_breaker_open_duration_milliseconds", 30000, 1, math.MaxInt32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid circuit breaker open duration milliseconds value")
+	}
+	halfOpenProbes, err := cfg.Properties.ParseIntWithRange("circuit_breaker_half_open_probes", 1, 1, math.MaxInt32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid circuit breaker half open probes value")
+	}
+	cb := &CircuitBreakerMiddleware{
+		failureThreshold: failureThreshold,
+		openDuration:     time.Duration(openDuration) * time.Millisecond,
+		halfOpenProbes:   halfOpenProbes,
+		state:            CircuitBreakerStateDisabled,
+		exporter:         exporter,
+		metricReport: &metrics.Report{
+			Key:        fmt.Sprintf("%s-%s-%s", cfg.Name, cfg.Source.Kind, cfg.TargetKind()),
+			Binding:    cfg.Name,
+			SourceKind: cfg.Source.Kind,
+			TargetKind: cfg.TargetKind(),
+		},
+	}
+	if failureThreshold > 0 {
+		cb.setState(CircuitBreakerStateClosed)
+	}
+	return cb, nil
+}
+
+func (cb *CircuitBreakerMiddleware) State() CircuitBreakerState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if cb.state == CircuitBreakerStateOpen && time.Since(cb.openedAt) >= cb.openDuration {
+		return CircuitBreakerStateHalfOpen
+	}
+	return cb.state
+}
+
+func (cb *CircuitBreakerMiddleware) setState(state CircuitBreakerState) {
+	cb.state = state
+	cb.failures = 0
+	cb.probes = 0
+	cb.successes = 0
+	if state == CircuitBreakerStateOpen {
+		cb.openedAt = time.Now()
+	}
+	if cb.exporter != nil {
+		cb.exporter.ReportCircuitBreakerState(cb.metricReport, circuitBreakerStateValues[state])
+	}
+}
+
+func (cb *CircuitBreakerMiddleware) allow() bool {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	switch cb.state {
+	case CircuitBreakerStateOpen:
+		if time.Since(cb.openedAt) < cb.openDuration {
+			return false
+		}
+		cb.setState(CircuitBreakerStateHalfOpen)
+		cb.probes++
+		return true
+	case CircuitBreakerStateHalfOpen:
+		if cb.probes >= cb.halfOpenProbes {
+			return false
+		}
+		cb.probes++
+		return true
+	default:
+		return true
+	}
+}
+
+func (cb *CircuitBreakerMiddleware) record(err error) {
+	// a permanent error is caused by the request, not by the target health
+	if types.IsPermanentError(err) {
+		return
+	}
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	switch cb.state {
+	case CircuitBreakerStateClosed:
+		if err == nil {
+			cb.failures = 0
+			return
+		}
+		cb.failures++
+		if cb.failures >= cb.failureThreshold {
+			cb.setState(CircuitBreakerStateOpen)
+		}
+	case CircuitBreakerStateHalfOpen:
+		if err != nil {
+			cb.setState(CircuitBreakerStateOpen)
+			return
+		}
+		cb.successes++
+		if cb.successes >= cb.halfOpenProbes {
+			cb.setState(CircuitBreakerStateClosed)
+		}
+	}
+}
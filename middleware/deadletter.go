@@ -0,0 +1,228 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kubemq-io/kubemq-go"
+	"github.com/kubemq-io/kubemq-targets/config"
+	"github.com/kubemq-io/kubemq-targets/pkg/logger"
+	"github.com/kubemq-io/kubemq-targets/pkg/retry"
+	"github.com/kubemq-io/kubemq-targets/types"
+)
+
+var deadLetterChannelTypeMap = map[string]string{
+	"queue":        "queue",
+	"events-store": "events-store",
+	"events":       "events",
+	"":             "queue",
+}
+
+type DeadLetterMiddleware struct {
+	ctx         context.Context
+	binding     string
+	channel     string
+	channelType string
+	client      *kubemq.Client
+	log         *logger.Logger
+	send        func(ctx context.Context, deadLetter *types.DeadLetter) error
+	receive     func(ctx context.Context, max int, peek bool) ([]*deadLetterMessage, error)
+	resend      func(ctx context.Context, msg *deadLetterMessage) error
+}
+
+// deadLetterMessage is a message received from the dead letter queue
+type deadLetterMessage struct {
+	metadata string
+	body     []byte
+}
+
+func NewDeadLetterMiddleware(ctx context.Context, cfg config.BindingConfig, log *logger.Logger) (*DeadLetterMiddleware, error) {
+	dl := &DeadLetterMiddleware{
+		ctx:     ctx,
+		binding: cfg.Name,
+		log:     log,
+	}
+	dl.channel = cfg.Properties.ParseString("dead_letter_channel", "")
+	if dl.channel == "" {
+		return dl, nil
+	}
+	var err error
+	dl.channelType, err = cfg.Properties.ParseStringMap("dead_letter_channel_type", deadLetterChannelTypeMap)
+	if err != nil {
+		return nil, fmt.Errorf("invalid dead letter channel type value")
+	}
+	host, port, err := cfg.Properties.MustParseAddress("dead_letter_address", cfg.Source.Properties.ParseString("address", ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid dead letter address value, %w", err)
+	}
+	dl.client, err = kubemq.NewClient(ctx,
+		kubemq.WithAddress(host, port),
+		kubemq.WithClientId(cfg.Properties.ParseString("dead_letter_client_id", fmt.Sprintf("%s-dead-letter", cfg.Name))),
+		kubemq.WithTransportType(kubemq.TransportTypeGRPC),
+		kubemq.WithCheckConnection(true),
+		kubemq.WithAuthToken(cfg.Properties.ParseString("dead_letter_auth_token", cfg.Source.Properties.ParseString("auth_token", ""))),
+		kubemq.WithAutoReconnect(true))
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to dead letter channel, %w", err)
+	}
+	dl.send = dl.sendToChannel
+	dl.receive = dl.receiveFromQueue
+	dl.resend = dl.resendToQueue
+	return dl, nil
+}
+
+func (dl *DeadLetterMiddleware) sendToChannel(ctx context.Context, deadLetter *types.DeadLetter) error {
+	switch dl.channelType {
+	case "events":
+		return dl.client.NewEvent().
+			SetChannel(dl.channel).
+			SetMetadata(dl.binding).
+			SetBody(deadLetter.MarshalBinary()).
+			Send(ctx)
+	case "events-store":
+		result, err := dl.client.NewEventStore().
+			SetChannel(dl.channel).
+			SetMetadata(dl.binding).
+			SetBody(deadLetter.MarshalBinary()).
+			Send(ctx)
+		if err != nil {
+			return err
+		}
+		if result.Err != nil {
+			return result.Err
+		}
+		return nil
+	default:
+		result, err := dl.client.NewQueueMessage().
+			SetChannel(dl.channel).
+			SetMetadata(dl.binding).
+			SetBody(deadLetter.MarshalBinary()).
+			Send(ctx)
+		if err != nil {
+			return err
+		}
+		if result.IsError {
+			return fmt.Errorf("%s", result.Error)
+		}
+		return nil
+	}
+}
+
+func (dl *DeadLetterMiddleware) Enabled() bool {
+	return dl.send != nil
+}
+
+func (dl *DeadLetterMiddleware) receiveFromQueue(ctx context.Context, max int, peek bool) ([]*deadLetterMessage, error) {
+	resp, err := dl.client.NewReceiveQueueMessagesRequest().
+		SetChannel(dl.channel).
+		SetMaxNumberOfMessages(max).
+		SetWaitTimeSeconds(1).
+		SetIsPeak(peek).
+		Send(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if resp.IsError && len(resp.Messages) == 0 {
+		// an empty queue is reported as a timeout error
+		if strings.Contains(strings.ToLower(resp.Error), "timeout") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%s", resp.Error)
+	}
+	var list []*deadLetterMessage
+	for _, msg := range resp.Messages {
+		list = append(list, &deadLetterMessage{metadata: msg.Metadata, body: msg.Body})
+	}
+	return list, nil
+}
+
+func (dl *DeadLetterMiddleware) resendToQueue(ctx context.Context, msg *deadLetterMessage) error {
+	result, err := dl.client.NewQueueMessage().
+		SetChannel(dl.channel).
+		SetMetadata(msg.metadata).
+		SetBody(msg.body).
+		Send(ctx)
+	if err != nil {
+		return err
+	}
+	if result.IsError {
+		return fmt.Errorf("%s", result.Error)
+	}
+	return nil
+}
+
+// Receive takes up to max messages of the dead letter queue and returns the dead letters matched by keep,
+// the other messages are sent back to the queue. With peek the messages are read and left in the queue
+func (dl *DeadLetterMiddleware) Receive(ctx context.Context, max int, peek bool, keep func(deadLetter *types.DeadLetter) bool) ([]*types.DeadLetter, error) {
+	if dl.receive == nil {
+		return nil, fmt.Errorf("no dead letter channel is set")
+	}
+	if dl.channelType != "queue" {
+		return nil, fmt.Errorf("dead letter channel type %s cannot be received, only queue is supported", dl.channelType)
+	}
+	messages, err := dl.receive(ctx, max, peek)
+	if err != nil {
+		return nil, fmt.Errorf("error receiving from dead letter channel, %w", err)
+	}
+	var list []*types.DeadLetter
+	for _, msg := range messages {
+		deadLetter, err := types.ParseDeadLetter(msg.body)
+		if err == nil && keep(deadLetter) {
+			list = append(list, deadLetter)
+			continue
+		}
+		if peek {
+			continue
+		}
+		if err := dl.resend(ctx, msg); err != nil {
+			return list, fmt.Errorf("error returning message to dead letter channel, %w", err)
+		}
+	}
+	return list, nil
+}
+
+// Requeue sends back dead letters taken from the dead letter channel, with the binding context as the caller may be canceled
+func (dl *DeadLetterMiddleware) Requeue(deadLetters []*types.DeadLetter) error {
+	for i, deadLetter := range deadLetters {
+		if err := dl.send(dl.ctx, deadLetter); err != nil {
+			return fmt.Errorf("error returning %d dead letters to dead letter channel, %w", len(deadLetters)-i, err)
+		}
+	}
+	return nil
+}
+
+func (dl *DeadLetterMiddleware) Publish(request *types.Request, doErr error) {
+	if dl.send == nil {
+		return
+	}
+	deadLetter := types.NewDeadLetter().
+		SetBinding(dl.binding).
+		SetRequest(request).
+		SetError(doErr).
+		SetAttempts(attemptsCount(doErr))
+	if err := dl.send(dl.ctx, deadLetter); err != nil && dl.log != nil {
+		dl.log.Errorf("error sending request to dead letter channel %s, %s", dl.channel, err.Error())
+	}
+}
+
+func (dl *DeadLetterMiddleware) Close() error {
+	if dl.client == nil {
+		return nil
+	}
+	return dl.client.Close()
+}
+
+func attemptsCount(err error) int {
+	retryErr, ok := err.(retry.Error)
+	if !ok {
+		return 1
+	}
+	count := 0
+	for _, e := range retryErr.WrappedErrors() {
+		if e != nil {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"fmt"
+
+	"github.com/kubemq-io/kubemq-targets/pkg/filter"
+	"github.com/kubemq-io/kubemq-targets/types"
+)
+
+type FilterMiddleware struct {
+	expression *filter.Expression
+}
+
+func NewFilterMiddleware(meta types.Metadata) (*FilterMiddleware, error) {
+	fm := &FilterMiddleware{}
+	expr := meta.ParseString("filter", "")
+	if expr == "" {
+		return fm, nil
+	}
+	var err error
+	fm.expression, err = filter.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter value, %w", err)
+	}
+	return fm, nil
+}
+
+func (fm *FilterMiddleware) match(request *types.Request) bool {
+	if fm.expression == nil {
+		return true
+	}
+	return fm.expression.Match(request.Metadata, request.Data)
+}
+
+func newFilteredResponse() *types.Response {
+	return types.NewResponse().SetMetadataKeyValue("filtered", "true")
+}
@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/kubemq-io/kubemq-targets/config"
+	"github.com/kubemq-io/kubemq-targets/pkg/journal"
+	"github.com/kubemq-io/kubemq-targets/types"
+)
+
+const maxJournalSize = 100000
+
+type replayOfKey struct{}
+
+// WithReplayOf returns a context of the replay of the journal activity id, the journal records it in the activity replay_of
+func WithReplayOf(ctx context.Context, id int64) context.Context {
+	return context.WithValue(ctx, replayOfKey{}, id)
+}
+
+type JournalMiddleware struct {
+	binding string
+	journal *journal.Journal
+	log     *LogMiddleware
+}
+
+// NewJournalMiddleware returns the binding journal, the activities are written to the journal file with the log
+// redaction rules applied
+func NewJournalMiddleware(cfg config.BindingConfig, log *LogMiddleware) (*JournalMiddleware, error) {
+	jm := &JournalMiddleware{
+		binding: cfg.Name,
+		log:     log,
+	}
+	size, err := cfg.Properties.ParseIntWithRange("journal_size", 0, 0, maxJournalSize)
+	if err != nil {
+		return nil, fmt.Errorf("invalid journal size value, %w", err)
+	}
+	if size == 0 {
+		return jm, nil
+	}
+	path := ""
+	if dir := cfg.Properties.ParseString("journal_dir", ""); dir != "" {
+		path = filepath.Join(dir, cfg.Name+".jsonl")
+	}
+	var redact func(activity *types.Activity) *types.Activity
+	if log.Redacts() {
+		redact = log.RedactActivity
+	}
+	jm.journal, err = journal.New(size, path, redact)
+	if err != nil {
+		return nil, fmt.Errorf("error opening journal, %w", err)
+	}
+	return jm, nil
+}
+
+func (jm *JournalMiddleware) Enabled() bool {
+	return jm.journal != nil
+}
+
+// List returns the binding activities matching the filter, newest first
+func (jm *JournalMiddleware) List(filter journal.Filter) []*types.Activity {
+	if jm.journal == nil {
+		return nil
+	}
+	return jm.journal.List(filter)
+}
+
+func (jm *JournalMiddleware) Close() error {
+	if jm.journal == nil {
+		return nil
+	}
+	return jm.journal.Close()
+}
+
+func (jm *JournalMiddleware) record(activity *types.Activity) {
+	if err := jm.journal.Add(activity); err != nil {
+		jm.log.Errorf("error adding activity to journal, %s", err.Error())
+	}
+}
+
+func Journal(jm *JournalMiddleware) MiddlewareFunc {
+	return func(df Middleware) Middleware {
+		if !jm.Enabled() {
+			return df
+		}
+		return DoFunc(func(ctx context.Context, request *types.Request) (*types.Response, error) {
+			activity := types.NewActivity()
+			activity.Binding = jm.binding
+			activity.ReplayOf, _ = ctx.Value(replayOfKey{}).(int64)
+			if request != nil {
+				activity.Request = CopyRequest(request)
+			}
+			result, err := df.Do(ctx, request)
+			jm.record(activity.SetResult(result, err))
+			return result, err
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/kubemq-io/kubemq-targets/pkg/logger"
+	"github.com/kubemq-io/kubemq-targets/pkg/redact"
 	"github.com/kubemq-io/kubemq-targets/types"
 )
 
@@ -24,7 +25,9 @@ var logLevelMap = map[string]string{
 }
 
 type LogMiddleware struct {
-	minLevel LogLevelType
+	minLevel     LogLevelType
+	redactFields redact.Fields
+	redactBody   bool
 	*logger.Logger
 }
 
@@ -34,8 +37,10 @@ func NewLogMiddleware(name string, meta types.Metadata) (*LogMiddleware, error)
 		return nil, fmt.Errorf("invalid log level value, %w", err)
 	}
 	lm := &LogMiddleware{
-		minLevel: LogLevelTypeNoLog,
-		Logger:   logger.NewLogger(name, level),
+		minLevel:     LogLevelTypeNoLog,
+		redactFields: redact.NewFields(meta.ParseString("log_redact_fields", "")),
+		redactBody:   meta.ParseBool("log_redact_body", false),
+		Logger:       logger.NewLogger(name, level),
 	}
 	switch level {
 	case "debug":
@@ -47,3 +52,66 @@ func NewLogMiddleware(name string, meta types.Metadata) (*LogMiddleware, error)
 	}
 	return lm, nil
 }
+
+// Redacts returns true when redaction rules are set
+func (lm *LogMiddleware) Redacts() bool {
+	return lm.redactBody || len(lm.redactFields) > 0
+}
+
+// RedactActivity returns a copy of activity with the redaction rules applied to its request and response
+func (lm *LogMiddleware) RedactActivity(activity *types.Activity) *types.Activity {
+	redacted := *activity
+	redacted.Request = lm.RedactRequest(activity.Request)
+	redacted.Response = lm.RedactResponse(activity.Response)
+	redacted.Redacted = true
+	return &redacted
+}
+
+func (lm *LogMiddleware) data(data []byte) []byte {
+	if lm.redactBody && len(data) > 0 {
+		return []byte(redact.Mask)
+	}
+	return lm.redactFields.JSON(data)
+}
+
+func (lm *LogMiddleware) metadataString(meta types.Metadata) string {
+	return types.Metadata(lm.redactFields.Metadata(meta)).String()
+}
+
+// RedactRequest returns a copy of request with the redaction rules applied
+func (lm *LogMiddleware) RedactRequest(request *types.Request) *types.Request {
+	if request == nil {
+		return nil
+	}
+	return &types.Request{
+		Metadata: lm.redactFields.Metadata(request.Metadata),
+		Data:     lm.data(request.Data),
+	}
+}
+
+// RedactResponse returns a copy of response with the redaction rules applied
+func (lm *LogMiddleware) RedactResponse(response *types.Response) *types.Response {
+	if response == nil {
+		return nil
+	}
+	return &types.Response{
+		Metadata: lm.redactFields.Metadata(response.Metadata),
+		Data:     lm.data(response.Data),
+		IsError:  response.IsError,
+		Error:    response.Error,
+	}
+}
+
+func (lm *LogMiddleware) requestString(request *types.Request) string {
+	if request == nil {
+		return ""
+	}
+	return lm.RedactRequest(request).String()
+}
+
+func (lm *LogMiddleware) responseString(response *types.Response) string {
+	if response == nil {
+		return ""
+	}
+	return lm.RedactResponse(response).String()
+}
@@ -1,15 +1,26 @@
 package middleware
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/kubemq-io/kubemq-targets/config"
 	"github.com/kubemq-io/kubemq-targets/pkg/metrics"
+	"github.com/kubemq-io/kubemq-targets/types"
+)
+
+const (
+	errorClassTimeout     = "timeout"
+	errorClassCircuitOpen = "circuit_open"
+	// methodOther labels the request methods not supported by the target, keeping the method label bounded
+	methodOther = "other"
 )
 
 type MetricsMiddleware struct {
 	exporter     *metrics.Exporter
 	metricReport *metrics.Report
+	methods      map[string]bool
 }
 
 func NewMetricsMiddleware(cfg config.BindingConfig, exporter *metrics.Exporter) (*MetricsMiddleware, error) {
@@ -19,10 +30,10 @@ func NewMetricsMiddleware(cfg config.BindingConfig, exporter *metrics.Exporter)
 	m := &MetricsMiddleware{
 		exporter: exporter,
 		metricReport: &metrics.Report{
-			Key:            fmt.Sprintf("%s-%s-%s", cfg.Name, cfg.Source.Kind, cfg.Target.Kind),
+			Key:            fmt.Sprintf("%s-%s-%s", cfg.Name, cfg.Source.Kind, cfg.TargetKind()),
 			Binding:        cfg.Name,
 			SourceKind:     cfg.Source.Kind,
-			TargetKind:     cfg.Target.Kind,
+			TargetKind:     cfg.TargetKind(),
 			RequestCount:   0,
 			RequestVolume:  0,
 			ResponseCount:  0,
@@ -33,10 +44,57 @@ func NewMetricsMiddleware(cfg config.BindingConfig, exporter *metrics.Exporter)
 	return m, nil
 }
 
-func (m *MetricsMiddleware) clearReport() {
-	m.metricReport.ErrorsCount = 0
-	m.metricReport.ResponseVolume = 0
-	m.metricReport.ResponseCount = 0
-	m.metricReport.RequestVolume = 0
-	m.metricReport.RequestCount = 0
+// newReport returns an empty report of the binding labelled with the request method
+func (m *MetricsMiddleware) newReport(request *types.Request) *metrics.Report {
+	report := &metrics.Report{
+		Key:        m.metricReport.Key,
+		Binding:    m.metricReport.Binding,
+		SourceKind: m.metricReport.SourceKind,
+		TargetKind: m.metricReport.TargetKind,
+	}
+	if request != nil {
+		report.Method = m.method(request.Metadata["method"])
+	}
+	return report
+}
+
+// SetMethods sets the request methods reported in the method label, the other methods are reported as "other"
+func (m *MetricsMiddleware) SetMethods(methods []string) {
+	m.methods = map[string]bool{}
+	for _, method := range methods {
+		m.methods[method] = true
+	}
+}
+
+func (m *MetricsMiddleware) method(value string) string {
+	if value == "" || m.methods[value] {
+		return value
+	}
+	return methodOther
+}
+
+func (m *MetricsMiddleware) reportRetries(request *types.Request, retries int) {
+	if m == nil || retries <= 0 {
+		return
+	}
+	m.exporter.ReportRetries(m.newReport(request), float64(retries))
+}
+
+func (m *MetricsMiddleware) reportRateLimiterWait(wait time.Duration) {
+	if m == nil {
+		return
+	}
+	m.exporter.ReportRateLimiterWait(m.metricReport, wait)
+}
+
+// errorClass returns the metrics class of a failed execution
+func errorClass(err error) string {
+	switch {
+	case errors.Is(err, ErrTimeout):
+		return errorClassTimeout
+	case errors.Is(err, ErrCircuitOpen):
+		return errorClassCircuitOpen
+	default:
+		return string(types.ErrorClassOf(err))
+	}
 }
@@ -2,6 +2,9 @@ package middleware
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/kubemq-io/kubemq-targets/pkg/retry"
 	"github.com/kubemq-io/kubemq-targets/types"
@@ -25,23 +28,15 @@ func Log(log *LogMiddleware) MiddlewareFunc {
 			result, err := df.Do(ctx, request)
 			switch log.minLevel {
 			case "debug":
-				reqStr := ""
-				if request != nil {
-					reqStr = request.String()
-				}
-				resStr := ""
-				if result != nil {
-					resStr = result.String()
-				}
-				log.Infof("request: %s, response: %s, error:%+v", reqStr, resStr, err)
+				log.Infof("request: %s, response: %s, error:%+v", log.requestString(request), log.responseString(result), err)
 			case "info":
 				reqStr := ""
 				if request != nil {
-					reqStr = request.Metadata.String()
+					reqStr = log.metadataString(request.Metadata)
 				}
 				resStr := ""
 				if result != nil {
-					resStr = result.Metadata.String()
+					resStr = log.metadataString(result.Metadata)
 				}
 				if err != nil {
 					log.Errorf("error processing request: %s, response: %s, error:%s", reqStr, resStr, err.Error())
@@ -51,11 +46,11 @@ func Log(log *LogMiddleware) MiddlewareFunc {
 			case "error":
 				reqStr := ""
 				if request != nil {
-					reqStr = request.Metadata.String()
+					reqStr = log.metadataString(request.Metadata)
 				}
 				resStr := ""
 				if result != nil {
-					resStr = result.Metadata.String()
+					resStr = log.metadataString(result.Metadata)
 				}
 				if err != nil {
 					log.Errorf("error processing request: %s, response: %s, error:%s", reqStr, resStr, err.Error())
@@ -69,7 +64,9 @@ func Log(log *LogMiddleware) MiddlewareFunc {
 func RateLimiter(rl *RateLimitMiddleware) MiddlewareFunc {
 	return func(df Middleware) Middleware {
 		return DoFunc(func(ctx context.Context, request *types.Request) (*types.Response, error) {
+			start := time.Now()
 			rl.Take()
+			rl.metrics.reportRateLimiterWait(time.Since(start))
 			return df.Do(ctx, request)
 		})
 	}
@@ -79,14 +76,79 @@ func Retry(r *RetryMiddleware) MiddlewareFunc {
 	return func(df Middleware) Middleware {
 		return DoFunc(func(ctx context.Context, request *types.Request) (*types.Response, error) {
 			var resp *types.Response
+			attempts := 0
 			err := retry.Do(func() error {
+				attempts++
 				var doErr error
 				resp, doErr = df.Do(ctx, request)
-				if doErr != nil {
-					return doErr
-				}
-				return nil
+				return doErr
 			}, r.opts...)
+			r.metrics.reportRetries(request, attempts-1)
+			if resp != nil && r.attempts > 1 {
+				resp.SetMetadataKeyValue("attempts", strconv.Itoa(attempts))
+			}
+			return resp, err
+		})
+	}
+}
+
+func DeadLetter(dl *DeadLetterMiddleware) MiddlewareFunc {
+	return func(df Middleware) Middleware {
+		return DoFunc(func(ctx context.Context, request *types.Request) (*types.Response, error) {
+			resp, err := df.Do(ctx, request)
+			if err != nil {
+				dl.Publish(request, err)
+			}
+			return resp, err
+		})
+	}
+}
+
+func Timeout(tm *TimeoutMiddleware) MiddlewareFunc {
+	return func(df Middleware) Middleware {
+		return DoFunc(func(ctx context.Context, request *types.Request) (*types.Response, error) {
+			timeout, err := tm.requestTimeout(request)
+			if err != nil {
+				return nil, err
+			}
+			if timeout == 0 {
+				return df.Do(ctx, request)
+			}
+			timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			type result struct {
+				resp *types.Response
+				err  error
+			}
+			resultCh := make(chan result, 1)
+			go func() {
+				resp, err := df.Do(timeoutCtx, request)
+				resultCh <- result{resp: resp, err: err}
+			}()
+			select {
+			case r := <-resultCh:
+				return r.resp, r.err
+			case <-timeoutCtx.Done():
+				if ctx.Err() != nil {
+					return nil, ctx.Err()
+				}
+				return nil, fmt.Errorf("%w after %s", ErrTimeout, timeout)
+			}
+		})
+	}
+}
+
+func CircuitBreaker(cb *CircuitBreakerMiddleware) MiddlewareFunc {
+	return func(df Middleware) Middleware {
+		return DoFunc(func(ctx context.Context, request *types.Request) (*types.Response, error) {
+			if cb.failureThreshold == 0 {
+				return df.Do(ctx, request)
+			}
+			if !cb.allow() {
+				return nil, retry.Unrecoverable(ErrCircuitOpen)
+			}
+			resp, err := df.Do(ctx, request)
+			cb.record(err)
 			return resp, err
 		})
 	}
@@ -96,19 +158,35 @@ func Metric(m *MetricsMiddleware) MiddlewareFunc {
 	return func(df Middleware) Middleware {
 		return DoFunc(func(ctx context.Context, request *types.Request) (*types.Response, error) {
 			resp, err := df.Do(ctx, request)
-			m.clearReport()
+			report := m.newReport(request)
 			if request != nil {
-				m.metricReport.RequestVolume = request.Size()
-				m.metricReport.RequestCount = 1
+				report.RequestVolume = request.Size()
+				report.RequestCount = 1
 			}
 			if resp != nil {
-				m.metricReport.ResponseVolume = resp.Size()
-				m.metricReport.ResponseCount = 1
+				report.ResponseVolume = resp.Size()
+				report.ResponseCount = 1
 			}
 			if err != nil {
-				m.metricReport.ErrorsCount = 1
+				report.ErrorsCount = 1
+				report.ErrorClass = errorClass(err)
 			}
-			m.exporter.Report(m.metricReport)
+			m.exporter.Report(report)
+			return resp, err
+		})
+	}
+}
+
+// MetricTarget reports the latency and the in flight executions of the target Do call
+func MetricTarget(m *MetricsMiddleware) MiddlewareFunc {
+	return func(df Middleware) Middleware {
+		return DoFunc(func(ctx context.Context, request *types.Request) (*types.Response, error) {
+			report := m.newReport(request)
+			m.exporter.ReportTargetInFlight(report, 1)
+			start := time.Now()
+			resp, err := df.Do(ctx, request)
+			m.exporter.ReportTargetLatency(report, time.Since(start))
+			m.exporter.ReportTargetInFlight(report, -1)
 			return resp, err
 		})
 	}
@@ -125,6 +203,31 @@ func Metadata(m *MetadataMiddleware) MiddlewareFunc {
 	}
 }
 
+func Transform(tm *TransformMiddleware) MiddlewareFunc {
+	return func(df Middleware) Middleware {
+		return DoFunc(func(ctx context.Context, request *types.Request) (*types.Response, error) {
+			if tm.isEmpty() || request == nil {
+				return df.Do(ctx, request)
+			}
+			if err := tm.apply(request); err != nil {
+				return nil, err
+			}
+			return df.Do(ctx, request)
+		})
+	}
+}
+
+func Filter(fm *FilterMiddleware) MiddlewareFunc {
+	return func(df Middleware) Middleware {
+		return DoFunc(func(ctx context.Context, request *types.Request) (*types.Response, error) {
+			if request != nil && !fm.match(request) {
+				return newFilteredResponse(), nil
+			}
+			return df.Do(ctx, request)
+		})
+	}
+}
+
 func Chain(md Middleware, list ...MiddlewareFunc) Middleware {
 	chain := md
 	for _, middleware := range list {
@@ -2,16 +2,24 @@ package middleware
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"math"
+	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/kubemq-io/kubemq-targets/config"
+	"github.com/kubemq-io/kubemq-targets/pkg/journal"
 	"github.com/kubemq-io/kubemq-targets/pkg/logger"
 	"github.com/kubemq-io/kubemq-targets/pkg/metrics"
+	"github.com/kubemq-io/kubemq-targets/pkg/tracing"
 	"github.com/kubemq-io/kubemq-targets/types"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 )
 
 type mockTarget struct {
@@ -322,6 +330,130 @@ func TestClient_Retry(t *testing.T) {
 	}
 }
 
+func TestClient_Retry_ErrorClass(t *testing.T) {
+	tests := []struct {
+		name         string
+		mock         *mockTarget
+		meta         types.Metadata
+		wantExecuted int
+		wantMinTime  time.Duration
+		wantMaxTime  time.Duration
+		wantAttempts string
+	}{
+		{
+			name: "permanent error - no retries",
+			mock: &mockTarget{
+				request:  types.NewRequest(),
+				response: nil,
+				err:      types.NewPermanentError(fmt.Errorf("some-error")),
+			},
+			meta: map[string]string{
+				"retry_attempts":           "3",
+				"retry_delay_milliseconds": "10",
+				"retry_delay_type":         "fixed",
+			},
+			wantExecuted: 1,
+		},
+		{
+			name: "transient error - retries",
+			mock: &mockTarget{
+				request:  types.NewRequest(),
+				response: nil,
+				err:      types.NewTransientError(fmt.Errorf("some-error")),
+			},
+			meta: map[string]string{
+				"retry_attempts":           "3",
+				"retry_delay_milliseconds": "10",
+				"retry_delay_type":         "fixed",
+			},
+			wantExecuted: 3,
+		},
+		{
+			name: "throttled error - honors retry after",
+			mock: &mockTarget{
+				request:  types.NewRequest(),
+				response: nil,
+				err:      types.NewThrottledError(fmt.Errorf("some-error"), 300*time.Millisecond),
+			},
+			meta: map[string]string{
+				"retry_attempts":           "2",
+				"retry_delay_milliseconds": "10",
+				"retry_delay_type":         "fixed",
+			},
+			wantExecuted: 2,
+			wantMinTime:  300 * time.Millisecond,
+		},
+		{
+			name: "throttled error - bounded by max retry after",
+			mock: &mockTarget{
+				request:  types.NewRequest(),
+				response: nil,
+				err:      types.NewThrottledError(fmt.Errorf("some-error"), time.Hour),
+			},
+			meta: map[string]string{
+				"retry_attempts":                     "2",
+				"retry_delay_milliseconds":           "10",
+				"retry_delay_type":                   "fixed",
+				"retry_max_retry_after_milliseconds": "100",
+			},
+			wantExecuted: 2,
+			wantMinTime:  100 * time.Millisecond,
+		},
+		{
+			name: "throttled error - longest of retry after and delay",
+			mock: &mockTarget{
+				request:  types.NewRequest(),
+				response: nil,
+				err:      types.NewThrottledError(fmt.Errorf("some-error"), 500*time.Millisecond),
+			},
+			meta: map[string]string{
+				"retry_attempts":           "2",
+				"retry_delay_milliseconds": "1000",
+				"retry_delay_type":         "fixed",
+			},
+			wantExecuted: 2,
+			wantMinTime:  500 * time.Millisecond,
+			wantMaxTime:  590 * time.Millisecond,
+		},
+		{
+			name: "success - attempts recorded",
+			mock: &mockTarget{
+				request:  types.NewRequest(),
+				response: types.NewResponse(),
+				err:      nil,
+			},
+			meta: map[string]string{
+				"retry_attempts": "3",
+			},
+			wantExecuted: 1,
+			wantAttempts: "1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			r, err := NewRetryMiddleware(tt.meta, nil)
+			require.NoError(t, err)
+			md := Chain(tt.mock, Retry(r))
+			start := time.Now()
+			resp, err := md.Do(ctx, tt.mock.request)
+			require.Equal(t, tt.wantExecuted, tt.mock.executed)
+			require.GreaterOrEqual(t, time.Since(start), tt.wantMinTime)
+			if tt.wantMaxTime > 0 {
+				require.Less(t, time.Since(start), tt.wantMaxTime)
+			}
+			if tt.mock.err != nil {
+				require.Error(t, err)
+				require.Equal(t, types.ErrorClassOf(tt.mock.err), types.ErrorClassOf(err))
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantAttempts, resp.Metadata["attempts"])
+		})
+	}
+}
+
 func TestClient_Metric(t *testing.T) {
 	exporter, err := metrics.NewExporter()
 	require.NoError(t, err)
@@ -534,7 +666,367 @@ func TestClient_Log(t *testing.T) {
 	}
 }
 
-func TestClient_Chain(t *testing.T) {
+func TestClient_LogRedact(t *testing.T) {
+	request := types.NewRequest().
+		SetMetadataKeyValue("method", "set").
+		SetMetadataKeyValue("password", "secret").
+		SetData([]byte(`{"user":{"name":"a","card_number":"1234"}}`))
+	log, err := NewLogMiddleware("test", map[string]string{
+		"log_level":         "debug",
+		"log_redact_fields": "password,card_number",
+	})
+	require.NoError(t, err)
+	redacted := log.requestString(request)
+	require.NotContains(t, redacted, "secret")
+	require.Contains(t, log.metadataString(request.Metadata), `"method":"set"`)
+	require.Equal(t, `{"user":{"card_number":"******","name":"a"}}`, string(log.data(request.Data)))
+	require.Equal(t, "secret", request.Metadata["password"])
+
+	log, err = NewLogMiddleware("test", map[string]string{
+		"log_level":       "debug",
+		"log_redact_body": "true",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "******", string(log.data(request.Data)))
+	require.Equal(t, "", string(log.data(nil)))
+}
+
+func TestClient_Journal(t *testing.T) {
+	cfg := config.BindingConfig{
+		Name:       "binding-1",
+		Properties: map[string]string{"journal_size": "2"},
+	}
+	log, err := NewLogMiddleware("test", cfg.Properties)
+	require.NoError(t, err)
+	jm, err := NewJournalMiddleware(cfg, log)
+	require.NoError(t, err)
+	require.True(t, jm.Enabled())
+	defer jm.Close()
+	mock := &mockTarget{response: types.NewResponse().SetData([]byte("ok"))}
+	meta, err := NewMetadataMiddleware(map[string]string{"metadata": `{"method":"set"}`})
+	require.NoError(t, err)
+	md := Chain(mock, Metadata(meta), Journal(jm))
+	_, err = md.Do(context.Background(), types.NewRequest().SetData([]byte("data-1")))
+	require.NoError(t, err)
+	mock.err = fmt.Errorf("some-error")
+	_, err = md.Do(context.Background(), types.NewRequest().SetData([]byte("data-2")))
+	require.Error(t, err)
+
+	list := jm.List(journal.Filter{})
+	require.Len(t, list, 2)
+	require.Equal(t, types.ActivityStatusError, list[0].Status)
+	require.Equal(t, "some-error", list[0].Error)
+	require.Equal(t, []byte("data-2"), list[0].Request.Data)
+	require.Equal(t, types.ActivityStatusSuccess, list[1].Status)
+	require.Equal(t, "binding-1", list[1].Binding)
+	require.Empty(t, list[1].Request.Metadata, "journal should keep the request as received")
+	require.Len(t, jm.List(journal.Filter{Status: types.ActivityStatusSuccess}), 1)
+
+	disabled, err := NewJournalMiddleware(config.BindingConfig{Name: "binding-2"}, log)
+	require.NoError(t, err)
+	require.False(t, disabled.Enabled())
+	_, err = NewJournalMiddleware(config.BindingConfig{Properties: map[string]string{"journal_size": "-1"}}, log)
+	require.Error(t, err)
+}
+
+func TestClient_JournalRedact(t *testing.T) {
+	cfg := config.BindingConfig{
+		Name: "binding-1",
+		Properties: map[string]string{
+			"journal_size":      "10",
+			"journal_dir":       t.TempDir(),
+			"log_redact_fields": "password",
+		},
+	}
+	log, err := NewLogMiddleware("test", cfg.Properties)
+	require.NoError(t, err)
+	jm, err := NewJournalMiddleware(cfg, log)
+	require.NoError(t, err)
+	mock := &mockTarget{response: types.NewResponse().SetData([]byte(`{"password":"response-secret"}`))}
+	md := Chain(mock, Journal(jm))
+	_, err = md.Do(context.Background(), types.NewRequest().SetMetadataKeyValue("password", "request-secret"))
+	require.NoError(t, err)
+	list := jm.List(journal.Filter{})
+	require.Len(t, list, 1)
+	require.Equal(t, "request-secret", list[0].Request.Metadata["password"], "journal should keep the request in memory as received")
+	require.NoError(t, jm.Close())
+
+	data, err := ioutil.ReadFile(filepath.Join(cfg.Properties["journal_dir"], "binding-1.jsonl"))
+	require.NoError(t, err)
+	stored := &types.Activity{}
+	require.NoError(t, json.Unmarshal(data, stored))
+	require.True(t, stored.Redacted)
+	require.NotEqual(t, "request-secret", stored.Request.Metadata["password"])
+	require.NotContains(t, string(stored.Response.Data), "response-secret")
+}
+
+func TestClient_DeadLetter(t *testing.T) {
+	tests := []struct {
+		name           string
+		mock           *mockTarget
+		meta           types.Metadata
+		wantDeadLetter bool
+		wantAttempts   int
+	}{
+		{
+			name: "failed request with retries",
+			mock: &mockTarget{
+				request:  types.NewRequest().SetData([]byte("data")),
+				response: nil,
+				err:      fmt.Errorf("some-error"),
+				delay:    0,
+				executed: 0,
+			},
+			meta: map[string]string{
+				"retry_attempts":           "3",
+				"retry_delay_milliseconds": "10",
+				"retry_delay_type":         "fixed",
+			},
+			wantDeadLetter: true,
+			wantAttempts:   3,
+		},
+		{
+			name: "successful request",
+			mock: &mockTarget{
+				request:  types.NewRequest().SetData([]byte("data")),
+				response: types.NewResponse(),
+				err:      nil,
+				delay:    0,
+				executed: 0,
+			},
+			meta:           map[string]string{},
+			wantDeadLetter: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			var got *types.DeadLetter
+			dl := &DeadLetterMiddleware{
+				ctx:     ctx,
+				binding: "b-1",
+				send: func(ctx context.Context, deadLetter *types.DeadLetter) error {
+					got = deadLetter
+					return nil
+				},
+			}
+			r, err := NewRetryMiddleware(tt.meta, nil)
+			require.NoError(t, err)
+			md := Chain(tt.mock, Retry(r), DeadLetter(dl))
+			_, err = md.Do(ctx, tt.mock.request)
+			if !tt.wantDeadLetter {
+				require.NoError(t, err)
+				require.Nil(t, got)
+				return
+			}
+			require.Error(t, err)
+			require.NotNil(t, got)
+			require.Equal(t, "b-1", got.Binding)
+			require.Equal(t, tt.wantAttempts, got.Attempts)
+			require.EqualValues(t, tt.mock.request, got.Request)
+			parsed, err := types.ParseDeadLetter(got.MarshalBinary())
+			require.NoError(t, err)
+			require.EqualValues(t, got.Request.Data, parsed.Request.Data)
+			require.Equal(t, got.Error, parsed.Error)
+			require.Equal(t, got.Attempts, parsed.Attempts)
+		})
+	}
+}
+
+func TestClient_DeadLetter_Receive(t *testing.T) {
+	newMessage := func(binding string) *deadLetterMessage {
+		deadLetter := types.NewDeadLetter().SetBinding(binding).SetRequest(types.NewRequest().SetData([]byte(binding)))
+		return &deadLetterMessage{metadata: binding, body: deadLetter.MarshalBinary()}
+	}
+	var resent []*deadLetterMessage
+	dl := &DeadLetterMiddleware{
+		binding:     "b-1",
+		channel:     "dlq",
+		channelType: "queue",
+		receive: func(ctx context.Context, max int, peek bool) ([]*deadLetterMessage, error) {
+			return []*deadLetterMessage{newMessage("b-1"), newMessage("b-2"), {metadata: "b-3", body: []byte("invalid")}, newMessage("b-1")}, nil
+		},
+		resend: func(ctx context.Context, msg *deadLetterMessage) error {
+			resent = append(resent, msg)
+			return nil
+		},
+	}
+	keep := func(deadLetter *types.DeadLetter) bool {
+		return deadLetter.Binding == "b-1"
+	}
+	list, err := dl.Receive(context.Background(), 10, false, keep)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	require.Equal(t, []byte("b-1"), list[0].Request.Data)
+	require.Len(t, resent, 2)
+	require.Equal(t, "b-2", resent[0].metadata)
+	require.Equal(t, "b-3", resent[1].metadata)
+
+	resent = nil
+	list, err = dl.Receive(context.Background(), 10, true, keep)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	require.Empty(t, resent, "peeked messages should not be sent back")
+
+	dl.channelType = "events"
+	_, err = dl.Receive(context.Background(), 10, false, keep)
+	require.Error(t, err)
+	_, err = (&DeadLetterMiddleware{}).Receive(context.Background(), 10, false, keep)
+	require.Error(t, err)
+}
+
+func TestClient_DeadLetter_Requeue(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var sent []*types.DeadLetter
+	dl := &DeadLetterMiddleware{
+		ctx:     ctx,
+		binding: "b-1",
+		send: func(ctx context.Context, deadLetter *types.DeadLetter) error {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			sent = append(sent, deadLetter)
+			return nil
+		},
+	}
+	deadLetters := []*types.DeadLetter{types.NewDeadLetter().SetBinding("b-1"), types.NewDeadLetter().SetBinding("b-1")}
+	require.NoError(t, dl.Requeue(deadLetters))
+	require.Equal(t, deadLetters, sent)
+	require.NoError(t, dl.Requeue(nil))
+	cancel()
+	require.Error(t, dl.Requeue(deadLetters))
+}
+
+func TestClient_DeadLetter_Config(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	dl, err := NewDeadLetterMiddleware(ctx, config.BindingConfig{Name: "b-1", Properties: map[string]string{}}, nil)
+	require.NoError(t, err)
+	require.Nil(t, dl.send)
+	_, err = NewDeadLetterMiddleware(ctx, config.BindingConfig{
+		Name: "b-1",
+		Properties: map[string]string{
+			"dead_letter_channel":      "dlq",
+			"dead_letter_channel_type": "bad-type",
+		},
+	}, nil)
+	require.Error(t, err)
+	_, err = NewDeadLetterMiddleware(ctx, config.BindingConfig{
+		Name: "b-1",
+		Properties: map[string]string{
+			"dead_letter_channel": "dlq",
+		},
+	}, nil)
+	require.Error(t, err)
+}
+
+func TestClient_Timeout(t *testing.T) {
+	tests := []struct {
+		name        string
+		mock        *mockTarget
+		meta        types.Metadata
+		wantTimeout bool
+		wantErr     bool
+	}{
+		{
+			name: "no timeout",
+			mock: &mockTarget{
+				request:  types.NewRequest(),
+				response: types.NewResponse(),
+				err:      nil,
+				delay:    100 * time.Millisecond,
+				executed: 0,
+			},
+			meta:        map[string]string{},
+			wantTimeout: false,
+			wantErr:     false,
+		},
+		{
+			name: "request completed before timeout",
+			mock: &mockTarget{
+				request:  types.NewRequest(),
+				response: types.NewResponse(),
+				err:      nil,
+				delay:    10 * time.Millisecond,
+				executed: 0,
+			},
+			meta: map[string]string{
+				"timeout_milliseconds": "500",
+			},
+			wantTimeout: false,
+			wantErr:     false,
+		},
+		{
+			name: "request timeout",
+			mock: &mockTarget{
+				request:  types.NewRequest(),
+				response: types.NewResponse(),
+				err:      nil,
+				delay:    500 * time.Millisecond,
+				executed: 0,
+			},
+			meta: map[string]string{
+				"timeout_milliseconds": "50",
+			},
+			wantTimeout: true,
+			wantErr:     false,
+		},
+		{
+			name: "request metadata timeout override",
+			mock: &mockTarget{
+				request:  types.NewRequest().SetMetadataKeyValue("timeout_milliseconds", "50"),
+				response: types.NewResponse(),
+				err:      nil,
+				delay:    500 * time.Millisecond,
+				executed: 0,
+			},
+			meta: map[string]string{
+				"timeout_milliseconds": "5000",
+			},
+			wantTimeout: true,
+			wantErr:     false,
+		},
+		{
+			name: "bad timeout",
+			mock: &mockTarget{
+				request:  types.NewRequest(),
+				response: types.NewResponse(),
+				err:      nil,
+				delay:    0,
+				executed: 0,
+			},
+			meta: map[string]string{
+				"timeout_milliseconds": "-1",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			tm, err := NewTimeoutMiddleware(tt.meta)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			md := Chain(tt.mock, Timeout(tm))
+			resp, err := md.Do(ctx, tt.mock.request)
+			if tt.wantTimeout {
+				require.ErrorIs(t, err, ErrTimeout)
+				require.Nil(t, resp)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+		})
+	}
+}
+
+func TestClient_CircuitBreaker(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 	mock := &mockTarget{
@@ -544,19 +1036,744 @@ func TestClient_Chain(t *testing.T) {
 		delay:    0,
 		executed: 0,
 	}
-	meta := map[string]string{
-		"log_level":                     "debug",
-		"rate_per_seconds":              "1",
-		"retry_attempts":                "3",
-		"retry_delay_milliseconds":      "100",
-		"retry_max_jitter_milliseconds": "100",
-		"retry_delay_type":              "fixed",
-	}
-	log, err := NewLogMiddleware("test", meta)
-	require.NoError(t, err)
-	rl, err := NewRateLimitMiddleware(meta)
+	cb, err := NewCircuitBreakerMiddleware(config.BindingConfig{
+		Name: "b-1",
+		Properties: map[string]string{
+			"circuit_breaker_failure_threshold":          "2",
+			"circuit_breaker_open_duration_milliseconds": "200",
+			"circuit_breaker_half_open_probes":           "1",
+		},
+	}, nil)
 	require.NoError(t, err)
-	retry, err := NewRetryMiddleware(meta, logger.NewLogger("retry-logger"))
+	require.Equal(t, CircuitBreakerStateClosed, cb.State())
+	retry, err := NewRetryMiddleware(map[string]string{
+		"retry_attempts":           "5",
+		"retry_delay_milliseconds": "10",
+		"retry_delay_type":         "fixed",
+	}, nil)
+	require.NoError(t, err)
+	md := Chain(mock, CircuitBreaker(cb), Retry(retry))
+	_, err = md.Do(ctx, mock.request)
+	require.Error(t, err)
+	require.Equal(t, 2, mock.executed)
+	require.Equal(t, CircuitBreakerStateOpen, cb.State())
+
+	_, err = md.Do(ctx, mock.request)
+	require.Error(t, err)
+	require.Equal(t, 2, mock.executed)
+
+	time.Sleep(250 * time.Millisecond)
+	require.Equal(t, CircuitBreakerStateHalfOpen, cb.State())
+	mock.err = nil
+	mock.response = types.NewResponse()
+	resp, err := md.Do(ctx, mock.request)
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Equal(t, 3, mock.executed)
+	require.Equal(t, CircuitBreakerStateClosed, cb.State())
+
+	mock.err = types.NewPermanentError(fmt.Errorf("bad request"))
+	for i := 0; i < 3; i++ {
+		_, err = md.Do(ctx, mock.request)
+		require.Error(t, err)
+	}
+	require.Equal(t, CircuitBreakerStateClosed, cb.State(), "permanent errors should not open the circuit")
+}
+
+func TestClient_CircuitBreaker_Config(t *testing.T) {
+	tests := []struct {
+		name      string
+		meta      types.Metadata
+		wantState CircuitBreakerState
+		wantErr   bool
+	}{
+		{
+			name:      "disabled",
+			meta:      map[string]string{},
+			wantState: CircuitBreakerStateDisabled,
+			wantErr:   false,
+		},
+		{
+			name: "enabled",
+			meta: map[string]string{
+				"circuit_breaker_failure_threshold": "5",
+			},
+			wantState: CircuitBreakerStateClosed,
+			wantErr:   false,
+		},
+		{
+			name: "bad failure threshold",
+			meta: map[string]string{
+				"circuit_breaker_failure_threshold": "-1",
+			},
+			wantErr: true,
+		},
+		{
+			name: "bad open duration",
+			meta: map[string]string{
+				"circuit_breaker_failure_threshold":          "5",
+				"circuit_breaker_open_duration_milliseconds": "0",
+			},
+			wantErr: true,
+		},
+		{
+			name: "bad half open probes",
+			meta: map[string]string{
+				"circuit_breaker_failure_threshold": "5",
+				"circuit_breaker_half_open_probes":  "0",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cb, err := NewCircuitBreakerMiddleware(config.BindingConfig{Name: "b-1", Properties: tt.meta}, nil)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantState, cb.State())
+		})
+	}
+}
+
+func TestClient_Transform(t *testing.T) {
+	tests := []struct {
+		name     string
+		meta     types.Metadata
+		request  *types.Request
+		wantMeta types.Metadata
+		wantData []byte
+		wantErr  bool
+	}{
+		{
+			name:     "no transform",
+			meta:     map[string]string{},
+			request:  types.NewRequest().SetMetadataKeyValue("key", "value").SetData([]byte("data")),
+			wantMeta: map[string]string{"key": "value"},
+			wantData: []byte("data"),
+		},
+		{
+			name: "json path, literal and template metadata",
+			meta: map[string]string{
+				"transform_metadata": `{"key":"$.order.id","method":"set","url":"http://host/{{ .order.id }}?src={{ meta \"source\" }}","total":"$.order.total"}`,
+			},
+			request: types.NewRequest().
+				SetMetadataKeyValue("source", "queue").
+				SetData([]byte(`{"order":{"id":"o-1","total":10}}`)),
+			wantMeta: map[string]string{
+				"source": "queue",
+				"key":    "o-1",
+				"method": "set",
+				"url":    "http://host/o-1?src=queue",
+				"total":  "10",
+			},
+			wantData: []byte(`{"order":{"id":"o-1","total":10}}`),
+		},
+		{
+			name: "json path data",
+			meta: map[string]string{
+				"transform_data": "$.order",
+			},
+			request:  types.NewRequest().SetData([]byte(`{"order":{"id":"o-1"}}`)),
+			wantMeta: map[string]string{},
+			wantData: []byte(`{"id":"o-1"}`),
+		},
+		{
+			name: "template data",
+			meta: map[string]string{
+				"transform_data": `{"id":{{ json .order.id }}}`,
+			},
+			request:  types.NewRequest().SetData([]byte(`{"order":{"id":"o-1"}}`)),
+			wantMeta: map[string]string{},
+			wantData: []byte(`{"id":"o-1"}`),
+		},
+		{
+			name: "json path not found",
+			meta: map[string]string{
+				"transform_metadata": `{"key":"$.order.name"}`,
+			},
+			request: types.NewRequest().SetData([]byte(`{"order":{"id":"o-1"}}`)),
+			wantErr: true,
+		},
+		{
+			name: "request data not json",
+			meta: map[string]string{
+				"transform_metadata": `{"key":"$.order.id"}`,
+			},
+			request: types.NewRequest().SetData([]byte(`data`)),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			tm, err := NewTransformMiddleware(tt.meta)
+			require.NoError(t, err)
+			var got *types.Request
+			target := DoFunc(func(ctx context.Context, request *types.Request) (*types.Response, error) {
+				got = request
+				return types.NewResponse(), nil
+			})
+			md := Chain(target, Transform(tm))
+			_, err = md.Do(ctx, tt.request)
+			if tt.wantErr {
+				require.Error(t, err)
+				require.True(t, types.IsPermanentError(err))
+				require.Nil(t, got)
+				return
+			}
+			require.NoError(t, err)
+			require.EqualValues(t, tt.wantMeta, got.Metadata)
+			require.EqualValues(t, tt.wantData, got.Data)
+		})
+	}
+}
+
+func TestClient_Transform_Config(t *testing.T) {
+	_, err := NewTransformMiddleware(map[string]string{"transform_metadata": "bad-json"})
+	require.Error(t, err)
+	_, err = NewTransformMiddleware(map[string]string{"transform_metadata": `{"key":"$..id"}`})
+	require.Error(t, err)
+	_, err = NewTransformMiddleware(map[string]string{"transform_data": "{{ .id "})
+	require.Error(t, err)
+}
+
+func TestClient_Filter(t *testing.T) {
+	tests := []struct {
+		name         string
+		meta         types.Metadata
+		request      *types.Request
+		wantExecuted int
+		wantFiltered bool
+		wantErr      bool
+	}{
+		{
+			name:         "no filter",
+			meta:         map[string]string{},
+			request:      types.NewRequest(),
+			wantExecuted: 1,
+		},
+		{
+			name: "filter pass",
+			meta: map[string]string{
+				"filter": "metadata.method == 'set' && $.region == 'eu'",
+			},
+			request:      types.NewRequest().SetMetadataKeyValue("method", "set").SetData([]byte(`{"region":"eu"}`)),
+			wantExecuted: 1,
+		},
+		{
+			name: "filter drop",
+			meta: map[string]string{
+				"filter": "metadata.method == 'set' && $.region == 'eu'",
+			},
+			request:      types.NewRequest().SetMetadataKeyValue("method", "set").SetData([]byte(`{"region":"us"}`)),
+			wantExecuted: 0,
+			wantFiltered: true,
+		},
+		{
+			name: "bad filter",
+			meta: map[string]string{
+				"filter": "metadata.method ==",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			fm, err := NewFilterMiddleware(tt.meta)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			mock := &mockTarget{response: types.NewResponse()}
+			md := Chain(mock, Filter(fm))
+			resp, err := md.Do(ctx, tt.request)
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+			require.Equal(t, tt.wantExecuted, mock.executed)
+			require.Equal(t, tt.wantFiltered, resp.Metadata["filtered"] == "true")
+		})
+	}
+}
+
+func TestClient_Router(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	orders := &mockTarget{response: types.NewResponse().SetData([]byte("orders"))}
+	audit := &mockTarget{response: types.NewResponse().SetData([]byte("audit"))}
+	ordersRoute, err := NewRoute("orders", "metadata.type == 'order'", orders)
+	require.NoError(t, err)
+	auditRoute, err := NewRoute("audit", "", audit)
+	require.NoError(t, err)
+	_, err = NewRoute("bad", "metadata.type ==", audit)
+	require.Error(t, err)
+	_, err = NewRouterMiddleware(nil, "")
+	require.Error(t, err)
+	_, err = NewRouterMiddleware([]*Route{ordersRoute, auditRoute}, "bad-strategy")
+	require.Error(t, err)
+	router, err := NewRouterMiddleware([]*Route{ordersRoute, auditRoute}, "")
+	require.NoError(t, err)
+
+	resp, err := router.Do(ctx, types.NewRequest().SetMetadataKeyValue("type", "payment"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("audit"), resp.Data)
+	require.Equal(t, 0, orders.executed)
+	require.Equal(t, 1, audit.executed)
+
+	resp, err = router.Do(ctx, types.NewRequest().SetMetadataKeyValue("type", "order"))
+	require.NoError(t, err)
+	require.Equal(t, "2", resp.Metadata["targets"])
+	var results []*RouteResult
+	require.NoError(t, json.Unmarshal(resp.Data, &results))
+	require.Len(t, results, 2)
+	require.Equal(t, "orders", results[0].Target)
+	require.Equal(t, []byte("orders"), results[0].Response.Data)
+	require.Equal(t, 1, orders.executed)
+	require.Equal(t, 2, audit.executed)
+
+	orders.err = fmt.Errorf("some-error")
+	request := types.NewRequest().SetMetadataKeyValue("type", "order")
+	_, err = router.Do(ctx, request)
+	require.Error(t, err)
+	require.Equal(t, 3, audit.executed)
+	require.Equal(t, "audit", request.Metadata[DeliveredTargetsKey])
+
+	orders.err = nil
+	resp, err = router.Do(ctx, request)
+	require.NoError(t, err)
+	require.Equal(t, 3, audit.executed, "retried request should not be sent again to delivered targets")
+	require.Equal(t, 3, orders.executed)
+	results = nil
+	require.NoError(t, json.Unmarshal(resp.Data, &results))
+	require.Len(t, results, 2)
+	require.Equal(t, []byte("orders"), results[0].Response.Data)
+	require.True(t, results[1].Delivered)
+	require.Equal(t, "orders,audit", request.Metadata[DeliveredTargetsKey])
+}
+
+func TestClient_Router_Strategy(t *testing.T) {
+	tests := []struct {
+		name          string
+		strategy      string
+		firstErr      error
+		requests      int
+		wantExecuted  []int
+		wantData      []byte
+		wantErr       bool
+		wantAggregate bool
+	}{
+		{
+			name:          "broadcast",
+			strategy:      "broadcast",
+			requests:      2,
+			wantExecuted:  []int{2, 2},
+			wantAggregate: true,
+		},
+		{
+			name:         "failover - first success",
+			strategy:     "failover",
+			requests:     2,
+			wantExecuted: []int{2, 0},
+			wantData:     []byte("first"),
+		},
+		{
+			name:         "failover - first failed",
+			strategy:     "failover",
+			firstErr:     fmt.Errorf("some-error"),
+			requests:     2,
+			wantExecuted: []int{2, 2},
+			wantData:     []byte("second"),
+		},
+		{
+			name:         "round-robin",
+			strategy:     "round-robin",
+			requests:     4,
+			wantExecuted: []int{2, 2},
+		},
+		{
+			name:         "broadcast - target failed",
+			strategy:     "broadcast",
+			firstErr:     fmt.Errorf("some-error"),
+			requests:     1,
+			wantExecuted: []int{1, 1},
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			first := &mockTarget{response: types.NewResponse().SetData([]byte("first")), err: tt.firstErr}
+			second := &mockTarget{response: types.NewResponse().SetData([]byte("second"))}
+			firstRoute, err := NewRoute("first", "", first)
+			require.NoError(t, err)
+			secondRoute, err := NewRoute("second", "", second)
+			require.NoError(t, err)
+			router, err := NewRouterMiddleware([]*Route{firstRoute, secondRoute}, tt.strategy)
+			require.NoError(t, err)
+			for i := 0; i < tt.requests; i++ {
+				resp, err := router.Do(ctx, types.NewRequest())
+				if tt.wantErr {
+					require.Error(t, err)
+					continue
+				}
+				require.NoError(t, err)
+				if tt.wantAggregate {
+					require.Equal(t, "2", resp.Metadata["targets"])
+				}
+				if tt.wantData != nil {
+					require.Equal(t, tt.wantData, resp.Data)
+				}
+			}
+			require.Equal(t, tt.wantExecuted, []int{first.executed, second.executed})
+		})
+	}
+}
+
+func TestClient_Router_FailoverCopy(t *testing.T) {
+	var seen []string
+	mutate := func(value string, err error) Middleware {
+		return DoFunc(func(ctx context.Context, request *types.Request) (*types.Response, error) {
+			seen = append(seen, request.Metadata["key"])
+			request.SetMetadataKeyValue("key", value)
+			return types.NewResponse(), err
+		})
+	}
+	firstRoute, err := NewRoute("first", "", mutate("first", fmt.Errorf("some-error")))
+	require.NoError(t, err)
+	secondRoute, err := NewRoute("second", "", mutate("second", nil))
+	require.NoError(t, err)
+	router, err := NewRouterMiddleware([]*Route{firstRoute, secondRoute}, "failover")
+	require.NoError(t, err)
+	request := types.NewRequest().SetMetadataKeyValue("key", "original")
+	_, err = router.Do(context.Background(), request)
+	require.NoError(t, err)
+	require.Equal(t, []string{"original", "original"}, seen)
+	require.Equal(t, "original", request.Metadata["key"])
+}
+
+func TestClient_Router_ErrorClass(t *testing.T) {
+	tests := []struct {
+		name           string
+		strategy       string
+		errs           []error
+		wantClass      types.ErrorClass
+		wantRetryAfter time.Duration
+	}{
+		{
+			name:      "broadcast - all failures permanent",
+			strategy:  "broadcast",
+			errs:      []error{types.NewPermanentError(fmt.Errorf("bad request")), types.NewPermanentError(fmt.Errorf("bad request"))},
+			wantClass: types.ErrorClassPermanent,
+		},
+		{
+			name:      "broadcast - one failure transient",
+			strategy:  "broadcast",
+			errs:      []error{types.NewPermanentError(fmt.Errorf("bad request")), fmt.Errorf("connection refused")},
+			wantClass: types.ErrorClassTransient,
+		},
+		{
+			name:           "broadcast - longest throttled failure",
+			strategy:       "broadcast",
+			errs:           []error{types.NewThrottledError(fmt.Errorf("slow down"), time.Second), types.NewThrottledError(fmt.Errorf("slow down"), 2*time.Second)},
+			wantClass:      types.ErrorClassThrottled,
+			wantRetryAfter: 2 * time.Second,
+		},
+		{
+			name:      "broadcast - one target failed",
+			strategy:  "broadcast",
+			errs:      []error{types.NewPermanentError(fmt.Errorf("bad request")), nil},
+			wantClass: types.ErrorClassPermanent,
+		},
+		{
+			name:      "failover - all failures permanent",
+			strategy:  "failover",
+			errs:      []error{types.NewPermanentError(fmt.Errorf("bad request")), types.NewPermanentError(fmt.Errorf("bad request"))},
+			wantClass: types.ErrorClassPermanent,
+		},
+		{
+			name:           "failover - throttled failure",
+			strategy:       "failover",
+			errs:           []error{types.NewPermanentError(fmt.Errorf("bad request")), types.NewThrottledError(fmt.Errorf("slow down"), time.Second)},
+			wantClass:      types.ErrorClassThrottled,
+			wantRetryAfter: time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var routes []*Route
+			for i, err := range tt.errs {
+				route, routeErr := NewRoute(fmt.Sprintf("target-%d", i), "", &mockTarget{response: types.NewResponse(), err: err})
+				require.NoError(t, routeErr)
+				routes = append(routes, route)
+			}
+			router, err := NewRouterMiddleware(routes, tt.strategy)
+			require.NoError(t, err)
+			_, err = router.Do(context.Background(), types.NewRequest())
+			require.Error(t, err)
+			require.Equal(t, tt.wantClass, types.ErrorClassOf(err))
+			require.Equal(t, tt.wantRetryAfter, types.RetryAfterOf(err))
+			for i, routeErr := range tt.errs {
+				if routeErr != nil {
+					require.Contains(t, err.Error(), fmt.Sprintf("target-%d: %s", i, routeErr.Error()))
+				}
+			}
+		})
+	}
+}
+
+func TestStripRouterMetadata(t *testing.T) {
+	var seen types.Metadata
+	next := DoFunc(func(ctx context.Context, request *types.Request) (*types.Response, error) {
+		seen = request.Metadata
+		return types.NewResponse(), nil
+	})
+	request := types.NewRequest().
+		SetMetadataKeyValue(DeliveredTargetsKey, "audit").
+		SetMetadataKeyValue("key", "value")
+	_, err := Chain(next, StripRouterMetadata).Do(context.Background(), request)
+	require.NoError(t, err)
+	require.Equal(t, types.Metadata{"key": "value"}, seen)
+	_, err = Chain(next, StripRouterMetadata).Do(context.Background(), types.NewRequest())
+	require.NoError(t, err)
+}
+
+type mockBatchTarget struct {
+	mu      sync.Mutex
+	batches []int
+	failAt  map[string]bool
+	invalid bool
+}
+
+func (m *mockBatchTarget) DoBatch(ctx context.Context, requests []*types.Request) ([]*types.Response, []error) {
+	m.mu.Lock()
+	m.batches = append(m.batches, len(requests))
+	m.mu.Unlock()
+	if m.invalid {
+		return nil, nil
+	}
+	responses := make([]*types.Response, len(requests))
+	errs := make([]error, len(requests))
+	for i, req := range requests {
+		if m.failAt[string(req.Data)] {
+			errs[i] = fmt.Errorf("failed %s", req.Data)
+			continue
+		}
+		responses[i] = types.NewResponse().SetData(req.Data)
+	}
+	return responses, errs
+}
+
+func TestClient_Batch(t *testing.T) {
+	tests := []struct {
+		name        string
+		mock        *mockBatchTarget
+		meta        types.Metadata
+		requests    int
+		wantBatches []int
+		wantErrs    int
+	}{
+		{
+			name: "flush on max items",
+			mock: &mockBatchTarget{},
+			meta: map[string]string{
+				"batch_max_items":             "2",
+				"batch_max_wait_milliseconds": "10000",
+			},
+			requests:    4,
+			wantBatches: []int{2, 2},
+			wantErrs:    0,
+		},
+		{
+			name: "flush on max wait",
+			mock: &mockBatchTarget{},
+			meta: map[string]string{
+				"batch_max_items":             "10",
+				"batch_max_wait_milliseconds": "100",
+			},
+			requests:    3,
+			wantBatches: []int{3},
+			wantErrs:    0,
+		},
+		{
+			name: "flush on max bytes",
+			mock: &mockBatchTarget{},
+			meta: map[string]string{
+				"batch_max_items":             "10",
+				"batch_max_bytes":             "2",
+				"batch_max_wait_milliseconds": "10000",
+			},
+			requests:    4,
+			wantBatches: []int{2, 2},
+			wantErrs:    0,
+		},
+		{
+			name: "flush on max bytes only",
+			mock: &mockBatchTarget{},
+			meta: map[string]string{
+				"batch_max_bytes":             "3",
+				"batch_max_wait_milliseconds": "10000",
+			},
+			requests:    6,
+			wantBatches: []int{3, 3},
+			wantErrs:    0,
+		},
+		{
+			name: "per request errors",
+			mock: &mockBatchTarget{
+				failAt: map[string]bool{"1": true},
+			},
+			meta: map[string]string{
+				"batch_max_items": "3",
+			},
+			requests:    3,
+			wantBatches: []int{3},
+			wantErrs:    1,
+		},
+		{
+			name: "invalid batch results",
+			mock: &mockBatchTarget{
+				invalid: true,
+			},
+			meta: map[string]string{
+				"batch_max_items": "2",
+			},
+			requests:    2,
+			wantBatches: []int{2},
+			wantErrs:    2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			bm, err := NewBatchMiddleware(tt.meta)
+			require.NoError(t, err)
+			require.True(t, bm.Enabled())
+			bm.Start(tt.mock.DoBatch)
+			defer bm.Close()
+			wg := sync.WaitGroup{}
+			mu := sync.Mutex{}
+			errs := 0
+			for i := 0; i < tt.requests; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					data := []byte(fmt.Sprintf("%d", i))
+					resp, err := bm.Do(ctx, types.NewRequest().SetData(data))
+					mu.Lock()
+					defer mu.Unlock()
+					if err != nil {
+						errs++
+						return
+					}
+					require.Equal(t, data, resp.Data)
+				}(i)
+			}
+			wg.Wait()
+			require.Equal(t, tt.wantErrs, errs)
+			require.Equal(t, tt.wantBatches, tt.mock.batches)
+		})
+	}
+}
+
+func TestClient_Batch_Close(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	mock := &mockBatchTarget{}
+	bm, err := NewBatchMiddleware(map[string]string{
+		"batch_max_items":             "10",
+		"batch_max_wait_milliseconds": "10000",
+	})
+	require.NoError(t, err)
+	bm.Start(mock.DoBatch)
+	done := make(chan error, 1)
+	go func() {
+		_, err := bm.Do(ctx, types.NewRequest().SetData([]byte("data")))
+		done <- err
+	}()
+	time.Sleep(100 * time.Millisecond)
+	bm.Close()
+	require.NoError(t, <-done)
+	require.Equal(t, []int{1}, mock.batches)
+	_, err = bm.Do(ctx, types.NewRequest())
+	require.ErrorIs(t, err, ErrBatchClosed)
+}
+
+func TestClient_Batch_Flush(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	mock := &mockBatchTarget{}
+	bm, err := NewBatchMiddleware(map[string]string{
+		"batch_max_items":             "10",
+		"batch_max_wait_milliseconds": "10000",
+	})
+	require.NoError(t, err)
+	bm.Start(mock.DoBatch)
+	defer bm.Close()
+	done := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, err := bm.Do(ctx, types.NewRequest().SetData([]byte("data")))
+			done <- err
+		}()
+	}
+	time.Sleep(100 * time.Millisecond)
+	bm.Flush()
+	require.NoError(t, <-done)
+	require.NoError(t, <-done)
+	_, err = bm.Do(ctx, types.NewRequest().SetData([]byte("data")))
+	require.NoError(t, err)
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+	require.Equal(t, []int{2, 1}, mock.batches)
+}
+
+func TestClient_Batch_Config(t *testing.T) {
+	bm, err := NewBatchMiddleware(map[string]string{})
+	require.NoError(t, err)
+	require.False(t, bm.Enabled())
+	bm, err = NewBatchMiddleware(map[string]string{"batch_max_bytes": "1024"})
+	require.NoError(t, err)
+	require.True(t, bm.Enabled())
+	bm, err = NewBatchMiddleware(map[string]string{"batch_max_items": "1", "batch_max_bytes": "1024"})
+	require.NoError(t, err)
+	require.False(t, bm.Enabled())
+	_, err = NewBatchMiddleware(map[string]string{"batch_max_items": "-1"})
+	require.Error(t, err)
+	_, err = NewBatchMiddleware(map[string]string{"batch_max_wait_milliseconds": "0"})
+	require.Error(t, err)
+}
+
+func TestClient_Chain(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	mock := &mockTarget{
+		request:  types.NewRequest(),
+		response: nil,
+		err:      fmt.Errorf("some-error"),
+		delay:    0,
+		executed: 0,
+	}
+	meta := map[string]string{
+		"log_level":                     "debug",
+		"rate_per_seconds":              "1",
+		"retry_attempts":                "3",
+		"retry_delay_milliseconds":      "100",
+		"retry_max_jitter_milliseconds": "100",
+		"retry_delay_type":              "fixed",
+	}
+	log, err := NewLogMiddleware("test", meta)
+	require.NoError(t, err)
+	rl, err := NewRateLimitMiddleware(meta)
+	require.NoError(t, err)
+	retry, err := NewRetryMiddleware(meta, logger.NewLogger("retry-logger"))
 	require.NoError(t, err)
 	md := Chain(mock, RateLimiter(rl), Retry(retry), Log(log))
 	start := time.Now()
@@ -564,3 +1781,121 @@ func TestClient_Chain(t *testing.T) {
 	d := time.Since(start)
 	require.GreaterOrEqual(t, d.Milliseconds(), 2*time.Second.Milliseconds())
 }
+
+func TestClient_Tracing(t *testing.T) {
+	provider, exporter := tracing.NewInMemoryProvider()
+	defer func() {
+		_ = provider.Shutdown(context.Background())
+	}()
+	mock := &mockTarget{
+		request:  types.NewRequest().SetMetadataKeyValue("method", "get"),
+		response: types.NewResponse(),
+		err:      fmt.Errorf("some-error"),
+	}
+	tr := NewTracingMiddleware(config.BindingConfig{
+		Name: "some-binding",
+		Source: config.Spec{
+			Kind: "kubemq.events",
+		},
+		Target: config.Spec{
+			Name: "some-target",
+			Kind: "http",
+		},
+	})
+	meta, err := NewMetadataMiddleware(map[string]string{})
+	require.NoError(t, err)
+	md := Chain(mock, TraceTarget(tr), Traced(tr, "metadata", Metadata(meta)))
+	_, err = md.Do(context.Background(), mock.request)
+	require.Error(t, err)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	targetSpan, stageSpan := spans[0], spans[1]
+	require.Equal(t, "target.do", targetSpan.Name)
+	require.Equal(t, "middleware.metadata", stageSpan.Name)
+	require.Equal(t, stageSpan.SpanContext.SpanID(), targetSpan.Parent.SpanID())
+	require.Equal(t, codes.Error, targetSpan.Status.Code)
+	attrs := map[attribute.Key]string{}
+	for _, kv := range targetSpan.Attributes {
+		attrs[kv.Key] = kv.Value.AsString()
+	}
+	require.Equal(t, "some-binding", attrs[tracing.BindingKey])
+	require.Equal(t, "http", attrs[tracing.TargetKindKey])
+	require.Equal(t, "some-target", attrs[tracing.TargetNameKey])
+	require.Equal(t, "get", attrs[tracing.MethodKey])
+}
+
+func TestClient_MetricErrorClass(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "unclassified",
+			err:  fmt.Errorf("some-error"),
+			want: "transient",
+		},
+		{
+			name: "permanent",
+			err:  types.NewPermanentError(fmt.Errorf("some-error")),
+			want: "permanent",
+		},
+		{
+			name: "throttled",
+			err:  types.NewThrottledError(fmt.Errorf("some-error"), time.Second),
+			want: "throttled",
+		},
+		{
+			name: "timeout",
+			err:  fmt.Errorf("%w after %s", ErrTimeout, time.Second),
+			want: "timeout",
+		},
+		{
+			name: "circuit open",
+			err:  ErrCircuitOpen,
+			want: "circuit_open",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, errorClass(tt.err))
+		})
+	}
+}
+
+func TestMetricsMiddleware_Method(t *testing.T) {
+	m := &MetricsMiddleware{metricReport: &metrics.Report{}}
+	m.SetMethods([]string{"get", "set"})
+	tests := []struct {
+		name    string
+		request *types.Request
+		want    string
+	}{
+		{
+			name:    "supported method",
+			request: types.NewRequest().SetMetadataKeyValue("method", "get"),
+			want:    "get",
+		},
+		{
+			name:    "unsupported method",
+			request: types.NewRequest().SetMetadataKeyValue("method", "some-random-value"),
+			want:    "other",
+		},
+		{
+			name:    "no method",
+			request: types.NewRequest(),
+			want:    "",
+		},
+		{
+			name:    "no request",
+			request: nil,
+			want:    "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, m.newReport(tt.request).Method)
+		})
+	}
+}
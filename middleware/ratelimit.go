@@ -10,6 +10,7 @@ import (
 
 type RateLimitMiddleware struct {
 	rateLimiter ratelimit.Limiter
+	metrics     *MetricsMiddleware
 }
 
 func NewRateLimitMiddleware(meta types.Metadata) (*RateLimitMiddleware, error) {
@@ -29,3 +30,8 @@ func NewRateLimitMiddleware(meta types.Metadata) (*RateLimitMiddleware, error) {
 func (rl *RateLimitMiddleware) Take() {
 	_ = rl.rateLimiter.Take()
 }
+
+// SetMetrics reports the time requests wait for the rate limiter to m
+func (rl *RateLimitMiddleware) SetMetrics(m *MetricsMiddleware) {
+	rl.metrics = m
+}
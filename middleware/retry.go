@@ -18,7 +18,10 @@ var delayTypeMap = map[string]string{
 }
 
 type RetryMiddleware struct {
-	opts []retry.Option
+	opts          []retry.Option
+	attempts      int
+	maxRetryAfter time.Duration
+	metrics       *MetricsMiddleware
 }
 
 func parseRetryOptions(meta types.Metadata) ([]retry.Option, error) {
@@ -53,6 +56,9 @@ func parseRetryOptions(meta types.Metadata) ([]retry.Option, error) {
 	case "random":
 		opts = append(opts, retry.DelayType(retry.RandomDelay))
 	}
+	opts = append(opts, retry.RetryIf(func(err error) bool {
+		return retry.IsRecoverable(err) && !types.IsPermanentError(err)
+	}))
 	return opts, nil
 }
 
@@ -61,12 +67,35 @@ func NewRetryMiddleware(meta types.Metadata, log *logger.Logger) (*RetryMiddlewa
 	if err != nil {
 		return nil, fmt.Errorf("error parsing retry options, %w", err)
 	}
+	attempts, _ := meta.ParseIntWithRange("retry_attempts", 1, 1, math.MaxInt32)
+	maxRetryAfter, err := meta.ParseIntWithRange("retry_max_retry_after_milliseconds", 60000, 0, math.MaxInt32)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing retry options, invalid retry max retry after milliseconds value")
+	}
 	if log != nil {
 		opts = append(opts, retry.OnRetry(func(n uint, err error) {
 			log.Errorf("retry %d failed, error: %s", n, err.Error())
 		}))
 	}
-	return &RetryMiddleware{
-		opts: opts,
-	}, nil
+	r := &RetryMiddleware{
+		attempts:      attempts,
+		maxRetryAfter: time.Duration(maxRetryAfter) * time.Millisecond,
+	}
+	// a throttled request is retried after the longest of its retry hint and the retry delay
+	r.opts = append(opts, retry.RetryAfter(r.retryAfter))
+	return r, nil
+}
+
+// SetMetrics reports the retry attempts of each request to m
+func (r *RetryMiddleware) SetMetrics(m *MetricsMiddleware) {
+	r.metrics = m
+}
+
+// retryAfter returns the retry hint of a throttled error, bounded by maxRetryAfter
+func (r *RetryMiddleware) retryAfter(err error) time.Duration {
+	retryAfter := types.RetryAfterOf(err)
+	if retryAfter > r.maxRetryAfter {
+		return r.maxRetryAfter
+	}
+	return retryAfter
 }
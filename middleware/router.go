@@ -0,0 +1,236 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/kubemq-io/kubemq-targets/pkg/filter"
+	"github.com/kubemq-io/kubemq-targets/types"
+)
+
+type Route struct {
+	Name   string
+	Filter *filter.Expression
+	Target Middleware
+}
+
+func NewRoute(name, expr string, target Middleware) (*Route, error) {
+	r := &Route{
+		Name:   name,
+		Target: target,
+	}
+	if expr != "" {
+		var err error
+		r.Filter, err = filter.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid route %s filter value, %w", name, err)
+		}
+	}
+	return r, nil
+}
+
+func (r *Route) match(request *types.Request) bool {
+	return r.Filter == nil || r.Filter.Match(request.Metadata, request.Data)
+}
+
+type RouteResult struct {
+	Target    string          `json:"target"`
+	Response  *types.Response `json:"response,omitempty"`
+	Error     string          `json:"error,omitempty"`
+	Delivered bool            `json:"delivered,omitempty"`
+	err       error
+}
+
+// DeliveredTargetsKey is the request metadata key listing the broadcast targets which already succeeded,
+// so a retried or replayed request is sent again only to the targets which failed
+const DeliveredTargetsKey = "delivered_targets"
+
+// StripRouterMetadata removes the router metadata keys from the requests received from the sources,
+// so a producer cannot set them to skip targets
+func StripRouterMetadata(next Middleware) Middleware {
+	return DoFunc(func(ctx context.Context, request *types.Request) (*types.Response, error) {
+		if request != nil {
+			delete(request.Metadata, DeliveredTargetsKey)
+		}
+		return next.Do(ctx, request)
+	})
+}
+
+type RouterStrategy string
+
+const (
+	RouterStrategyBroadcast  RouterStrategy = "broadcast"
+	RouterStrategyFailover   RouterStrategy = "failover"
+	RouterStrategyRoundRobin RouterStrategy = "round-robin"
+)
+
+var routerStrategyMap = map[string]RouterStrategy{
+	"broadcast":   RouterStrategyBroadcast,
+	"failover":    RouterStrategyFailover,
+	"round-robin": RouterStrategyRoundRobin,
+	"":            RouterStrategyBroadcast,
+}
+
+// RouterMiddleware sends each request to the routes its filter matches according to the router strategy:
+// broadcast to all of them, failover to the first one that succeeds or round-robin to one of them
+type RouterMiddleware struct {
+	next     uint64
+	routes   []*Route
+	strategy RouterStrategy
+}
+
+func NewRouterMiddleware(routes []*Route, strategy string) (*RouterMiddleware, error) {
+	if len(routes) == 0 {
+		return nil, fmt.Errorf("router must have at least one route")
+	}
+	rs, ok := routerStrategyMap[strategy]
+	if !ok {
+		return nil, fmt.Errorf("invalid router strategy %s", strategy)
+	}
+	return &RouterMiddleware{
+		routes:   routes,
+		strategy: rs,
+	}, nil
+}
+
+func (rm *RouterMiddleware) Do(ctx context.Context, request *types.Request) (*types.Response, error) {
+	var matched []*Route
+	for _, route := range rm.routes {
+		if route.match(request) {
+			matched = append(matched, route)
+		}
+	}
+	switch {
+	case len(matched) == 0:
+		return newFilteredResponse(), nil
+	case len(matched) == 1:
+		return matched[0].Target.Do(ctx, request)
+	}
+	switch rm.strategy {
+	case RouterStrategyFailover:
+		return rm.failover(ctx, request, matched)
+	case RouterStrategyRoundRobin:
+		index := atomic.AddUint64(&rm.next, 1) - 1
+		return matched[index%uint64(len(matched))].Target.Do(ctx, request)
+	default:
+		return rm.broadcast(ctx, request, matched)
+	}
+}
+
+func (rm *RouterMiddleware) broadcast(ctx context.Context, request *types.Request, routes []*Route) (*types.Response, error) {
+	delivered := map[string]bool{}
+	for _, name := range strings.Split(request.Metadata[DeliveredTargetsKey], ",") {
+		if name != "" {
+			delivered[name] = true
+		}
+	}
+	results := make([]*RouteResult, len(routes))
+	wg := sync.WaitGroup{}
+	for i, route := range routes {
+		if delivered[route.Name] {
+			results[i] = &RouteResult{Target: route.Name, Delivered: true}
+			continue
+		}
+		wg.Add(1)
+		go func(i int, route *Route) {
+			defer wg.Done()
+			routeRequest := CopyRequest(request)
+			delete(routeRequest.Metadata, DeliveredTargetsKey)
+			resp, err := route.Target.Do(ctx, routeRequest)
+			results[i] = newRouteResult(route.Name, resp, err)
+		}(i, route)
+	}
+	wg.Wait()
+	var names []string
+	for _, result := range results {
+		if result.Error == "" {
+			names = append(names, result.Target)
+		}
+	}
+	if len(names) > 0 {
+		if request.Metadata == nil {
+			request.Metadata = types.NewMetadata()
+		}
+		request.SetMetadataKeyValue(DeliveredTargetsKey, strings.Join(names, ","))
+	}
+	return aggregateResults(results)
+}
+
+func (rm *RouterMiddleware) failover(ctx context.Context, request *types.Request, routes []*Route) (*types.Response, error) {
+	var failures []*RouteResult
+	for _, route := range routes {
+		resp, err := route.Target.Do(ctx, CopyRequest(request))
+		if err == nil {
+			return resp, nil
+		}
+		failures = append(failures, newRouteResult(route.Name, nil, err))
+	}
+	return nil, routesError(fmt.Sprintf("all %d targets failed", len(routes)), failures)
+}
+
+// CopyRequest returns a copy of request with its own metadata, as middlewares may change it
+func CopyRequest(request *types.Request) *types.Request {
+	metadata := types.NewMetadata()
+	for key, val := range request.Metadata {
+		metadata.Set(key, val)
+	}
+	return types.NewRequest().SetMetadata(metadata).SetData(request.Data)
+}
+
+func newRouteResult(name string, resp *types.Response, err error) *RouteResult {
+	result := &RouteResult{
+		Target:   name,
+		Response: resp,
+	}
+	if err != nil {
+		result.Error = err.Error()
+		result.err = err
+	}
+	return result
+}
+
+// routesError returns the error of the failed routes classified as their cause: the first failure which is not permanent,
+// preferring the throttled one with the longest retry after, or the first failure when all of them are permanent
+func routesError(prefix string, failures []*RouteResult) error {
+	cause := failures[0]
+	for _, failure := range failures[1:] {
+		switch {
+		case types.IsPermanentError(failure.err):
+		case types.IsPermanentError(cause.err):
+			cause = failure
+		case types.RetryAfterOf(failure.err) > types.RetryAfterOf(cause.err):
+			cause = failure
+		}
+	}
+	var others []string
+	for _, failure := range failures {
+		if failure != cause {
+			others = append(others, fmt.Sprintf("%s: %s; ", failure.Target, failure.Error))
+		}
+	}
+	return fmt.Errorf("%s, %s%s: %w", prefix, strings.Join(others, ""), cause.Target, cause.err)
+}
+
+// aggregateResults merges the results of several targets into one response, failing if any target failed with the class of routesError
+func aggregateResults(results []*RouteResult) (*types.Response, error) {
+	var failures []*RouteResult
+	for _, result := range results {
+		if result.err != nil {
+			failures = append(failures, result)
+		}
+	}
+	if len(failures) > 0 {
+		return nil, routesError(fmt.Sprintf("%d of %d targets failed", len(failures), len(results)), failures)
+	}
+	data, err := json.Marshal(results)
+	if err != nil {
+		return nil, fmt.Errorf("error aggregating targets responses, %w", err)
+	}
+	return types.NewResponse().
+		SetMetadataKeyValue("targets", fmt.Sprintf("%d", len(results))).
+		SetData(data), nil
+}
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/kubemq-io/kubemq-targets/types"
+)
+
+var ErrTimeout = errors.New("target execution timeout")
+
+type TimeoutMiddleware struct {
+	timeout time.Duration
+}
+
+func NewTimeoutMiddleware(meta types.Metadata) (*TimeoutMiddleware, error) {
+	timeout, err := meta.ParseIntWithRange("timeout_milliseconds", 0, 0, math.MaxInt32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timeout milliseconds value, %w", err)
+	}
+	return &TimeoutMiddleware{
+		timeout: time.Duration(timeout) * time.Millisecond,
+	}, nil
+}
+
+func (tm *TimeoutMiddleware) requestTimeout(request *types.Request) (time.Duration, error) {
+	if request == nil || request.Metadata == nil {
+		return tm.timeout, nil
+	}
+	timeout, err := request.Metadata.ParseIntWithRange("timeout_milliseconds", int(tm.timeout.Milliseconds()), 0, math.MaxInt32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid request timeout milliseconds value, %w", err)
+	}
+	return time.Duration(timeout) * time.Millisecond, nil
+}
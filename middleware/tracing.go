@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/kubemq-io/kubemq-targets/config"
+	"github.com/kubemq-io/kubemq-targets/pkg/tracing"
+	"github.com/kubemq-io/kubemq-targets/types"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type TracingMiddleware struct {
+	attributes []attribute.KeyValue
+}
+
+func NewTracingMiddleware(cfg config.BindingConfig) *TracingMiddleware {
+	attributes := []attribute.KeyValue{
+		tracing.BindingKey.String(cfg.Name),
+		tracing.SourceKindKey.String(cfg.Source.Kind),
+		tracing.TargetKindKey.String(cfg.TargetKind()),
+	}
+	if len(cfg.Targets) == 0 && cfg.Target.Name != "" {
+		attributes = append(attributes, tracing.TargetNameKey.String(cfg.Target.Name))
+	}
+	return &TracingMiddleware{
+		attributes: attributes,
+	}
+}
+
+func (t *TracingMiddleware) start(ctx context.Context, name string, kind trace.SpanKind, request *types.Request, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	attrs = append(attrs, t.attributes...)
+	if request != nil {
+		if method, ok := request.Metadata["method"]; ok {
+			attrs = append(attrs, tracing.MethodKey.String(method))
+		}
+	}
+	return tracing.StartSpan(ctx, name, kind, attrs...)
+}
+
+// Traced wraps a middleware stage with a span named after the stage
+func Traced(t *TracingMiddleware, stage string, mf MiddlewareFunc) MiddlewareFunc {
+	return func(df Middleware) Middleware {
+		next := mf(df)
+		return DoFunc(func(ctx context.Context, request *types.Request) (*types.Response, error) {
+			ctx, span := t.start(ctx, "middleware."+stage, trace.SpanKindInternal, request, tracing.StageKey.String(stage))
+			resp, err := next.Do(ctx, request)
+			tracing.EndSpan(span, err)
+			return resp, err
+		})
+	}
+}
+
+// TraceTarget wraps the target Do call with a client span
+func TraceTarget(t *TracingMiddleware) MiddlewareFunc {
+	return func(df Middleware) Middleware {
+		return DoFunc(func(ctx context.Context, request *types.Request) (*types.Response, error) {
+			ctx, span := t.start(ctx, "target.do", trace.SpanKindClient, request)
+			resp, err := df.Do(ctx, request)
+			if err == nil && resp != nil && resp.IsError {
+				span.SetAttributes(attribute.String("error", resp.Error))
+			}
+			tracing.EndSpan(span, err)
+			return resp, err
+		})
+	}
+}
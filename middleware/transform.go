@@ -0,0 +1,163 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/kubemq-io/kubemq-targets/pkg/jsonpath"
+	"github.com/kubemq-io/kubemq-targets/types"
+)
+
+type transformExpr struct {
+	literal string
+	path    *jsonpath.Path
+	tmpl    *template.Template
+}
+
+func newTransformExpr(name, expr string) (*transformExpr, error) {
+	switch {
+	case strings.HasPrefix(expr, "$"):
+		path, err := jsonpath.Compile(expr)
+		if err != nil {
+			return nil, err
+		}
+		return &transformExpr{path: path}, nil
+	case strings.Contains(expr, "{{"):
+		tmpl, err := template.New(name).
+			Option("missingkey=error").
+			Funcs(template.FuncMap{"meta": func(string) string { return "" }, "json": toJson}).
+			Parse(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid template %s, %w", expr, err)
+		}
+		return &transformExpr{tmpl: tmpl}, nil
+	default:
+		return &transformExpr{literal: expr}, nil
+	}
+}
+
+func (e *transformExpr) needsBody() bool {
+	return e.path != nil || e.tmpl != nil
+}
+
+func (e *transformExpr) eval(body interface{}, request *types.Request) (interface{}, error) {
+	switch {
+	case e.path != nil:
+		return e.path.Get(body)
+	case e.tmpl != nil:
+		tmpl, err := e.tmpl.Clone()
+		if err != nil {
+			return nil, err
+		}
+		tmpl.Funcs(template.FuncMap{"meta": request.Metadata.Get})
+		buf := &bytes.Buffer{}
+		if err := tmpl.Execute(buf, body); err != nil {
+			return nil, err
+		}
+		return buf.String(), nil
+	default:
+		return e.literal, nil
+	}
+}
+
+type TransformMiddleware struct {
+	metadata map[string]*transformExpr
+	data     *transformExpr
+}
+
+func NewTransformMiddleware(meta types.Metadata) (*TransformMiddleware, error) {
+	tm := &TransformMiddleware{
+		metadata: map[string]*transformExpr{},
+	}
+	metadataExpressions, err := meta.MustParseJsonMap("transform_metadata")
+	if err != nil {
+		return nil, fmt.Errorf("invalid transform metadata value, %w", err)
+	}
+	for key, expr := range metadataExpressions {
+		tm.metadata[key], err = newTransformExpr(key, expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid transform metadata %s expression, %w", key, err)
+		}
+	}
+	if dataExpr := meta.ParseString("transform_data", ""); dataExpr != "" {
+		tm.data, err = newTransformExpr("data", dataExpr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid transform data expression, %w", err)
+		}
+	}
+	return tm, nil
+}
+
+func (tm *TransformMiddleware) isEmpty() bool {
+	return len(tm.metadata) == 0 && tm.data == nil
+}
+
+func (tm *TransformMiddleware) needsBody() bool {
+	if tm.data != nil && tm.data.needsBody() {
+		return true
+	}
+	for _, expr := range tm.metadata {
+		if expr.needsBody() {
+			return true
+		}
+	}
+	return false
+}
+
+func (tm *TransformMiddleware) apply(request *types.Request) error {
+	var body interface{}
+	if tm.needsBody() {
+		if err := json.Unmarshal(request.Data, &body); err != nil {
+			return types.NewPermanentError(fmt.Errorf("transform error, request data is not a valid json, %w", err))
+		}
+	}
+	if request.Metadata == nil {
+		request.Metadata = types.NewMetadata()
+	}
+	for key, expr := range tm.metadata {
+		val, err := expr.eval(body, request)
+		if err != nil {
+			return types.NewPermanentError(fmt.Errorf("transform error on metadata %s, %w", key, err))
+		}
+		request.SetMetadataKeyValue(key, toString(val))
+	}
+	if tm.data != nil {
+		val, err := tm.data.eval(body, request)
+		if err != nil {
+			return types.NewPermanentError(fmt.Errorf("transform error on data, %w", err))
+		}
+		if str, ok := val.(string); ok {
+			request.SetData([]byte(str))
+		} else {
+			request.SetData([]byte(toJson(val)))
+		}
+	}
+	return nil
+}
+
+func toString(val interface{}) string {
+	switch v := val.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return toJson(v)
+	}
+}
+
+func toJson(val interface{}) string {
+	data, err := json.Marshal(val)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
@@ -0,0 +1,342 @@
+package filter
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/kubemq-io/kubemq-targets/pkg/jsonpath"
+)
+
+// Expression is a compiled filter expression evaluated against request metadata and json data.
+//
+// Operands are metadata.<key>, a json path on the data ($.order.id), 'strings', numbers, true, false and null.
+// Operands can be compared with ==, !=, >, >=, <, <=, =~ and !~ (regex match), combined with &&, || and !,
+// and grouped with parentheses. An operand on its own is true when it exists and is not false or empty.
+type Expression struct {
+	expr      string
+	root      node
+	needsData bool
+}
+
+type env struct {
+	metadata map[string]string
+	data     interface{}
+}
+
+type node interface {
+	eval(e *env) interface{}
+}
+
+type literalNode struct {
+	value interface{}
+}
+
+func (n *literalNode) eval(_ *env) interface{} {
+	return n.value
+}
+
+type metadataNode struct {
+	key string
+}
+
+func (n *metadataNode) eval(e *env) interface{} {
+	val, ok := e.metadata[n.key]
+	if !ok {
+		return nil
+	}
+	return val
+}
+
+type pathNode struct {
+	path *jsonpath.Path
+}
+
+func (n *pathNode) eval(e *env) interface{} {
+	if e.data == nil {
+		return nil
+	}
+	val, err := n.path.Get(e.data)
+	if err != nil {
+		return nil
+	}
+	return val
+}
+
+type notNode struct {
+	operand node
+}
+
+func (n *notNode) eval(e *env) interface{} {
+	return !isTrue(n.operand.eval(e))
+}
+
+type logicalNode struct {
+	and         bool
+	left, right node
+}
+
+func (n *logicalNode) eval(e *env) interface{} {
+	left := isTrue(n.left.eval(e))
+	if n.and {
+		return left && isTrue(n.right.eval(e))
+	}
+	return left || isTrue(n.right.eval(e))
+}
+
+type compareNode struct {
+	op          string
+	left, right node
+	re          *regexp.Regexp
+}
+
+func (n *compareNode) eval(e *env) interface{} {
+	left := n.left.eval(e)
+	switch n.op {
+	case "=~":
+		return left != nil && n.re.MatchString(toString(left))
+	case "!~":
+		return left == nil || !n.re.MatchString(toString(left))
+	}
+	right := n.right.eval(e)
+	switch n.op {
+	case "==":
+		return equals(left, right)
+	case "!=":
+		return !equals(left, right)
+	}
+	l, lok := toNumber(left)
+	r, rok := toNumber(right)
+	if !lok || !rok {
+		return false
+	}
+	switch n.op {
+	case ">":
+		return l > r
+	case ">=":
+		return l >= r
+	case "<":
+		return l < r
+	default:
+		return l <= r
+	}
+}
+
+func Compile(expr string) (*Expression, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter expression %s, %w", expr, err)
+	}
+	p := &parser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter expression %s, %w", expr, err)
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, fmt.Errorf("invalid filter expression %s, unexpected %q at position %d", expr, t.value, t.pos)
+	}
+	return &Expression{
+		expr:      expr,
+		root:      root,
+		needsData: p.needsData,
+	}, nil
+}
+
+// Match evaluates the expression, data is parsed as json only when the expression refers to it
+func (e *Expression) Match(metadata map[string]string, data []byte) bool {
+	en := &env{metadata: metadata}
+	if e.needsData && len(data) > 0 {
+		_ = json.Unmarshal(data, &en.data)
+	}
+	return isTrue(e.root.eval(en))
+}
+
+func (e *Expression) String() string {
+	return e.expr
+}
+
+type parser struct {
+	tokens    []token
+	pos       int
+	needsData bool
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenOr {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenAnd {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalNode{and: true, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	switch p.peek().kind {
+	case tokenNot:
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{operand: operand}, nil
+	case tokenOpen:
+		p.next()
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if t := p.next(); t.kind != tokenClose {
+			return nil, fmt.Errorf("missing ) at position %d", t.pos)
+		}
+		return n, nil
+	default:
+		return p.parseComparison()
+	}
+}
+
+func (p *parser) parseComparison() (node, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().kind != tokenOperator {
+		return left, nil
+	}
+	op := p.next().value
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	n := &compareNode{op: op, left: left, right: right}
+	if op == "=~" || op == "!~" {
+		lit, ok := right.(*literalNode)
+		if !ok {
+			return nil, fmt.Errorf("regex operator %s requires a string pattern", op)
+		}
+		n.re, err = regexp.Compile(toString(lit.value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid regex pattern, %w", err)
+		}
+	}
+	return n, nil
+}
+
+func (p *parser) parseOperand() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenMetadata:
+		return &metadataNode{key: t.value}, nil
+	case tokenPath:
+		path, err := jsonpath.Compile(t.value)
+		if err != nil {
+			return nil, err
+		}
+		p.needsData = true
+		return &pathNode{path: path}, nil
+	case tokenString:
+		return &literalNode{value: t.value}, nil
+	case tokenBool:
+		return &literalNode{value: t.value == "true"}, nil
+	case tokenNull:
+		return &literalNode{value: nil}, nil
+	case tokenNumber:
+		number, err := strconv.ParseFloat(t.value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("unknown identifier %s at position %d", t.value, t.pos)
+		}
+		return &literalNode{value: number}, nil
+	case tokenEOF:
+		return nil, fmt.Errorf("unexpected end of expression")
+	default:
+		return nil, fmt.Errorf("unexpected %q at position %d", t.value, t.pos)
+	}
+}
+
+func isTrue(val interface{}) bool {
+	switch v := val.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != "" && v != "false"
+	default:
+		return true
+	}
+}
+
+func equals(left, right interface{}) bool {
+	if left == nil || right == nil {
+		return left == nil && right == nil
+	}
+	if l, ok := toNumber(left); ok {
+		if r, ok := toNumber(right); ok {
+			return l == r
+		}
+	}
+	return toString(left) == toString(right)
+}
+
+func toNumber(val interface{}) (float64, bool) {
+	switch v := val.(type) {
+	case float64:
+		return v, true
+	case string:
+		number, err := strconv.ParseFloat(v, 64)
+		return number, err == nil
+	default:
+		return 0, false
+	}
+}
+
+func toString(val interface{}) string {
+	switch v := val.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+}
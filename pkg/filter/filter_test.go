@@ -0,0 +1,64 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpression_Match(t *testing.T) {
+	metadata := map[string]string{
+		"method": "set",
+		"key":    "order-1",
+		"count":  "5",
+	}
+	data := []byte(`{"order":{"id":"o-1","total":99.5,"paid":true,"items":[{"sku":"a"}]},"region":"eu"}`)
+	tests := []struct {
+		name    string
+		expr    string
+		want    bool
+		wantErr bool
+	}{
+		{name: "metadata equals", expr: "metadata.method == 'set'", want: true},
+		{name: "metadata not equals", expr: `metadata.method != "set"`, want: false},
+		{name: "metadata exists", expr: "metadata.key", want: true},
+		{name: "metadata missing", expr: "metadata.url", want: false},
+		{name: "not metadata missing", expr: "!metadata.url", want: true},
+		{name: "metadata number compare", expr: "metadata.count > 3", want: true},
+		{name: "json path equals", expr: "$.order.id == 'o-1'", want: true},
+		{name: "json path number", expr: "$.order.total >= 100", want: false},
+		{name: "json path bool", expr: "$.order.paid", want: true},
+		{name: "json path index", expr: "$.order.items[0].sku == 'a'", want: true},
+		{name: "json path missing", expr: "$.order.name == null", want: true},
+		{name: "regex", expr: "metadata.key =~ '^order-[0-9]+$'", want: true},
+		{name: "not regex", expr: "$.region !~ '^us'", want: true},
+		{name: "and", expr: "metadata.method == 'set' && $.region == 'eu'", want: true},
+		{name: "or", expr: "metadata.method == 'get' || $.region == 'eu'", want: true},
+		{name: "precedence", expr: "metadata.method == 'get' && $.region == 'eu' || $.order.paid", want: true},
+		{name: "parentheses", expr: "metadata.method == 'get' && ($.region == 'eu' || $.order.paid)", want: false},
+		{name: "bad regex", expr: "metadata.key =~ '['", wantErr: true},
+		{name: "unknown identifier", expr: "method == 'set'", wantErr: true},
+		{name: "missing parenthesis", expr: "(metadata.key", wantErr: true},
+		{name: "missing operand", expr: "metadata.key ==", wantErr: true},
+		{name: "unterminated string", expr: "metadata.key == 'a", wantErr: true},
+		{name: "trailing token", expr: "metadata.key 'a'", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := Compile(tt.expr)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, e.Match(metadata, data))
+		})
+	}
+}
+
+func TestExpression_Match_NoJsonData(t *testing.T) {
+	e, err := Compile("$.order.id == 'o-1' || metadata.method == 'set'")
+	require.NoError(t, err)
+	require.True(t, e.Match(map[string]string{"method": "set"}, []byte("not-json")))
+	require.False(t, e.Match(map[string]string{}, nil))
+}
@@ -0,0 +1,127 @@
+package filter
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenMetadata
+	tokenPath
+	tokenString
+	tokenNumber
+	tokenBool
+	tokenNull
+	tokenOperator
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenOpen
+	tokenClose
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+const metadataPrefix = "metadata."
+
+var operators = []string{"==", "!=", "=~", "!~", ">=", "<=", ">", "<"}
+
+func isDelimiter(r byte) bool {
+	return strings.IndexByte(" \t\r\n()=!<>&|", r) != -1
+}
+
+func tokenize(expr string) ([]token, error) {
+	var tokens []token
+	i := 0
+	for i < len(expr) {
+		c := expr[i]
+		switch {
+		case unicode.IsSpace(rune(c)):
+			i++
+		case c == '(':
+			tokens = append(tokens, token{kind: tokenOpen, value: "(", pos: i})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{kind: tokenClose, value: ")", pos: i})
+			i++
+		case strings.HasPrefix(expr[i:], "&&"):
+			tokens = append(tokens, token{kind: tokenAnd, value: "&&", pos: i})
+			i += 2
+		case strings.HasPrefix(expr[i:], "||"):
+			tokens = append(tokens, token{kind: tokenOr, value: "||", pos: i})
+			i += 2
+		case c == '\'' || c == '"':
+			end := strings.IndexByte(expr[i+1:], c)
+			if end == -1 {
+				return nil, fmt.Errorf("unterminated string at position %d", i)
+			}
+			tokens = append(tokens, token{kind: tokenString, value: expr[i+1 : i+1+end], pos: i})
+			i += end + 2
+		case c == '$':
+			start := i
+			for i < len(expr) && !isDelimiter(expr[i]) {
+				if expr[i] == '[' {
+					end := strings.IndexByte(expr[i:], ']')
+					if end == -1 {
+						return nil, fmt.Errorf("invalid json path at position %d, missing ]", start)
+					}
+					i += end
+				}
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenPath, value: expr[start:i], pos: start})
+		default:
+			if op := matchOperator(expr[i:]); op != "" {
+				tokens = append(tokens, token{kind: tokenOperator, value: op, pos: i})
+				i += len(op)
+				continue
+			}
+			if c == '!' {
+				tokens = append(tokens, token{kind: tokenNot, value: "!", pos: i})
+				i++
+				continue
+			}
+			start := i
+			for i < len(expr) && !isDelimiter(expr[i]) {
+				i++
+			}
+			word := expr[start:i]
+			if word == "" {
+				return nil, fmt.Errorf("unexpected character %q at position %d", c, i)
+			}
+			tokens = append(tokens, wordToken(word, start))
+		}
+	}
+	tokens = append(tokens, token{kind: tokenEOF, pos: len(expr)})
+	return tokens, nil
+}
+
+func matchOperator(s string) string {
+	for _, op := range operators {
+		if strings.HasPrefix(s, op) {
+			return op
+		}
+	}
+	return ""
+}
+
+func wordToken(word string, pos int) token {
+	switch {
+	case word == "true" || word == "false":
+		return token{kind: tokenBool, value: word, pos: pos}
+	case word == "null":
+		return token{kind: tokenNull, value: word, pos: pos}
+	case strings.HasPrefix(word, metadataPrefix):
+		return token{kind: tokenMetadata, value: strings.TrimPrefix(word, metadataPrefix), pos: pos}
+	default:
+		return token{kind: tokenNumber, value: word, pos: pos}
+	}
+}
@@ -0,0 +1,194 @@
+package journal
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/kubemq-io/kubemq-targets/types"
+)
+
+// Filter selects journal activities, zero values match all
+type Filter struct {
+	Status string
+	Since  time.Time
+	Until  time.Time
+	Limit  int
+}
+
+func (f Filter) match(a *types.Activity) bool {
+	if f.Status != "" && a.Status != f.Status {
+		return false
+	}
+	if !f.Since.IsZero() && a.CreatedAt.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && a.CreatedAt.After(f.Until) {
+		return false
+	}
+	return true
+}
+
+// Journal keeps the last activities of a binding in memory, and in a json lines file when a path is set
+type Journal struct {
+	mu         sync.Mutex
+	size       int
+	lastId     int64
+	activities []*types.Activity
+	path       string
+	file       *os.File
+	lines      int
+	redact     func(activity *types.Activity) *types.Activity
+}
+
+// New returns a journal of size activities, the activities of an existing file at path are loaded. When redact is set,
+// the activities are written to the file as returned by redact and kept as is in memory only
+func New(size int, path string, redact func(activity *types.Activity) *types.Activity) (*Journal, error) {
+	j := &Journal{
+		size:   size,
+		path:   path,
+		redact: redact,
+	}
+	if path == "" {
+		return j, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("error creating journal dir, %w", err)
+	}
+	if err := j.load(); err != nil {
+		return nil, fmt.Errorf("error loading journal file, %w", err)
+	}
+	if err := j.compact(); err != nil {
+		return nil, fmt.Errorf("error writing journal file, %w", err)
+	}
+	return j, nil
+}
+
+func (j *Journal) load() error {
+	file, err := os.Open(j.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		activity := &types.Activity{}
+		if err := json.Unmarshal(scanner.Bytes(), activity); err != nil {
+			// a partially written last line is skipped
+			continue
+		}
+		j.append(activity)
+	}
+	return scanner.Err()
+}
+
+// line returns the json line of the activity written to the file
+func (j *Journal) line(activity *types.Activity) ([]byte, error) {
+	if j.redact != nil && !activity.Redacted {
+		activity = j.redact(activity)
+	}
+	line, err := json.Marshal(activity)
+	if err != nil {
+		return nil, err
+	}
+	return append(line, '\n'), nil
+}
+
+func (j *Journal) append(activity *types.Activity) {
+	if activity.Id > j.lastId {
+		j.lastId = activity.Id
+	}
+	j.activities = append(j.activities, activity)
+	if len(j.activities) > j.size {
+		j.activities = append([]*types.Activity{}, j.activities[len(j.activities)-j.size:]...)
+	}
+}
+
+// compact rewrites the file with the activities in memory
+func (j *Journal) compact() error {
+	tmp := j.path + ".tmp"
+	var data []byte
+	for _, activity := range j.activities {
+		line, err := j.line(activity)
+		if err != nil {
+			return err
+		}
+		data = append(data, line...)
+	}
+	if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	if j.file != nil {
+		_ = j.file.Close()
+		j.file = nil
+	}
+	if err := os.Rename(tmp, j.path); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(j.path, os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	j.file = file
+	j.lines = len(j.activities)
+	return nil
+}
+
+// Add assigns the activity id and stores it, dropping the oldest activity when the journal is full
+func (j *Journal) Add(activity *types.Activity) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	activity.Id = j.lastId + 1
+	j.append(activity)
+	if j.file == nil {
+		return nil
+	}
+	if j.lines >= 2*j.size {
+		return j.compact()
+	}
+	line, err := j.line(activity)
+	if err != nil {
+		return err
+	}
+	if _, err := j.file.Write(line); err != nil {
+		return err
+	}
+	j.lines++
+	return nil
+}
+
+// List returns the activities matching the filter, newest first
+func (j *Journal) List(filter Filter) []*types.Activity {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	var list []*types.Activity
+	for i := len(j.activities) - 1; i >= 0; i-- {
+		if filter.Limit > 0 && len(list) == filter.Limit {
+			break
+		}
+		if filter.match(j.activities[i]) {
+			list = append(list, j.activities[i])
+		}
+	}
+	return list
+}
+
+func (j *Journal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.file == nil {
+		return nil
+	}
+	err := j.file.Close()
+	j.file = nil
+	return err
+}
@@ -0,0 +1,96 @@
+package journal
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kubemq-io/kubemq-targets/types"
+	"github.com/stretchr/testify/require"
+)
+
+func newActivity(createdAt time.Time, err error) *types.Activity {
+	activity := types.NewActivity()
+	activity.Binding = "binding-1"
+	activity.CreatedAt = createdAt
+	activity.Request = types.NewRequest().SetData([]byte("data"))
+	return activity.SetResult(types.NewResponse(), err)
+}
+
+func TestJournal_List(t *testing.T) {
+	j, err := New(3, "", nil)
+	require.NoError(t, err)
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		var err error
+		if i%2 == 1 {
+			err = fmt.Errorf("some-error")
+		}
+		require.NoError(t, j.Add(newActivity(start.Add(time.Duration(i)*time.Second), err)))
+	}
+	ids := func(list []*types.Activity) []int64 {
+		var ids []int64
+		for _, activity := range list {
+			ids = append(ids, activity.Id)
+		}
+		return ids
+	}
+	require.Equal(t, []int64{5, 4, 3}, ids(j.List(Filter{})))
+	require.Equal(t, []int64{4}, ids(j.List(Filter{Status: types.ActivityStatusError})))
+	require.Equal(t, []int64{5}, ids(j.List(Filter{Limit: 1})))
+	require.Equal(t, []int64{4, 3}, ids(j.List(Filter{Until: start.Add(3 * time.Second)})))
+	require.Equal(t, []int64{5}, ids(j.List(Filter{Since: start.Add(4 * time.Second)})))
+}
+
+func TestJournal_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "binding-1.jsonl")
+	j, err := New(2, path, nil)
+	require.NoError(t, err)
+	for i := 0; i < 7; i++ {
+		require.NoError(t, j.Add(newActivity(time.Now(), nil)))
+	}
+	require.NoError(t, j.Close())
+
+	j, err = New(2, path, nil)
+	require.NoError(t, err)
+	defer j.Close()
+	list := j.List(Filter{})
+	require.Len(t, list, 2)
+	require.Equal(t, int64(7), list[0].Id)
+	require.Equal(t, []byte("data"), list[0].Request.Data)
+	require.NoError(t, j.Add(newActivity(time.Now(), nil)))
+	require.Equal(t, int64(8), j.List(Filter{Limit: 1})[0].Id)
+}
+
+func TestJournal_FileRedact(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "binding-1.jsonl")
+	redact := func(activity *types.Activity) *types.Activity {
+		redacted := *activity
+		redacted.Request = types.NewRequest().SetData([]byte("******"))
+		redacted.Redacted = true
+		return &redacted
+	}
+	j, err := New(2, path, redact)
+	require.NoError(t, err)
+	for i := 0; i < 5; i++ {
+		require.NoError(t, j.Add(newActivity(time.Now(), nil)))
+	}
+	list := j.List(Filter{})
+	require.Equal(t, []byte("data"), list[0].Request.Data)
+	require.False(t, list[0].Redacted)
+	require.NoError(t, j.Close())
+
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.NotContains(t, string(data), `"data":"ZGF0YQ=="`)
+
+	j, err = New(2, path, redact)
+	require.NoError(t, err)
+	defer j.Close()
+	list = j.List(Filter{})
+	require.Len(t, list, 2)
+	require.True(t, list[0].Redacted)
+	require.Equal(t, []byte("******"), list[0].Request.Data)
+}
@@ -0,0 +1,92 @@
+package jsonpath
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type step struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+// Path is a compiled JSONPath expression supporting child (.name, ['name']) and index ([n]) selectors
+type Path struct {
+	expr  string
+	steps []step
+}
+
+func Compile(expr string) (*Path, error) {
+	if !strings.HasPrefix(expr, "$") {
+		return nil, fmt.Errorf("invalid json path %s, must start with $", expr)
+	}
+	p := &Path{expr: expr}
+	rest := expr[1:]
+	for len(rest) > 0 {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end == -1 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("invalid json path %s, empty field name", expr)
+			}
+			p.steps = append(p.steps, step{key: rest[:end]})
+			rest = rest[end:]
+		case '[':
+			end := strings.Index(rest, "]")
+			if end == -1 {
+				return nil, fmt.Errorf("invalid json path %s, missing ]", expr)
+			}
+			selector := rest[1:end]
+			rest = rest[end+1:]
+			if len(selector) >= 2 && (selector[0] == '\'' || selector[0] == '"') && selector[len(selector)-1] == selector[0] {
+				p.steps = append(p.steps, step{key: selector[1 : len(selector)-1]})
+				continue
+			}
+			index, err := strconv.Atoi(selector)
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("invalid json path %s, invalid index %s", expr, selector)
+			}
+			p.steps = append(p.steps, step{index: index, isIndex: true})
+		default:
+			return nil, fmt.Errorf("invalid json path %s, unexpected character %q", expr, rest[0])
+		}
+	}
+	return p, nil
+}
+
+// Get returns the value selected by the path from a decoded json document
+func (p *Path) Get(data interface{}) (interface{}, error) {
+	current := data
+	for _, s := range p.steps {
+		if s.isIndex {
+			list, ok := current.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("json path %s, value is not an array", p.expr)
+			}
+			if s.index >= len(list) {
+				return nil, fmt.Errorf("json path %s, index %d out of range", p.expr, s.index)
+			}
+			current = list[s.index]
+			continue
+		}
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("json path %s, value is not an object", p.expr)
+		}
+		current, ok = object[s.key]
+		if !ok {
+			return nil, fmt.Errorf("json path %s, field %s not found", p.expr, s.key)
+		}
+	}
+	return current, nil
+}
+
+func (p *Path) String() string {
+	return p.expr
+}
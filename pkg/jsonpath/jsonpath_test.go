@@ -0,0 +1,51 @@
+package jsonpath
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPath_Get(t *testing.T) {
+	doc := map[string]interface{}{}
+	err := json.Unmarshal([]byte(`{"order":{"id":"o-1","items":[{"sku":"a"},{"sku":"b"}],"total":10.5},"my key":true}`), &doc)
+	require.NoError(t, err)
+	tests := []struct {
+		name       string
+		expr       string
+		want       interface{}
+		wantErr    bool
+		wantGetErr bool
+	}{
+		{name: "root", expr: "$", want: doc},
+		{name: "field", expr: "$.order.id", want: "o-1"},
+		{name: "number", expr: "$.order.total", want: 10.5},
+		{name: "index", expr: "$.order.items[1].sku", want: "b"},
+		{name: "quoted field", expr: "$['my key']", want: true},
+		{name: "not found", expr: "$.order.name", wantGetErr: true},
+		{name: "index out of range", expr: "$.order.items[2]", wantGetErr: true},
+		{name: "index on object", expr: "$.order[0]", wantGetErr: true},
+		{name: "no root", expr: "order.id", wantErr: true},
+		{name: "empty field", expr: "$..id", wantErr: true},
+		{name: "bad index", expr: "$.order.items[-1]", wantErr: true},
+		{name: "missing bracket", expr: "$.order.items[0", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := Compile(tt.expr)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			got, err := p.Get(doc)
+			if tt.wantGetErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.EqualValues(t, tt.want, got)
+		})
+	}
+}
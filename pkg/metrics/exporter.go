@@ -2,20 +2,31 @@ package metrics
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
-var labels = []string{"binding", "source_kind", "target_kind"}
+var (
+	labels        = []string{"binding", "source_kind", "target_kind"}
+	requestLabels = []string{"binding", "source_kind", "target_kind", "method"}
+	errorLabels   = []string{"binding", "source_kind", "target_kind", "method", "class"}
+)
 
 type Exporter struct {
-	Store                    *Store
-	requestsCollector        *promCounterMetric
-	responsesCollector       *promCounterMetric
-	requestsVolumeCollector  *promCounterMetric
-	responsesVolumeCollector *promCounterMetric
-	errorsCollector          *promCounterMetric
+	Store                     *Store
+	requestsCollector         *promCounterMetric
+	responsesCollector        *promCounterMetric
+	requestsVolumeCollector   *promCounterMetric
+	responsesVolumeCollector  *promCounterMetric
+	errorsCollector           *promCounterMetric
+	circuitBreakerCollector   *promGaugeMetric
+	sourceQueueDepthCollector *promGaugeMetric
+	targetLatencyCollector    *promHistogramMetric
+	targetInFlightCollector   *promGaugeMetric
+	retriesCollector          *promCounterMetric
+	rateLimiterWaitCollector  *promHistogramMetric
 }
 
 func (e *Exporter) PrometheusHandler() http.Handler {
@@ -24,12 +35,18 @@ func (e *Exporter) PrometheusHandler() http.Handler {
 
 func NewExporter() (*Exporter, error) {
 	e := &Exporter{
-		Store:                    NewStore(),
-		requestsCollector:        nil,
-		responsesCollector:       nil,
-		requestsVolumeCollector:  nil,
-		responsesVolumeCollector: nil,
-		errorsCollector:          nil,
+		Store:                     NewStore(),
+		requestsCollector:         nil,
+		responsesCollector:        nil,
+		requestsVolumeCollector:   nil,
+		responsesVolumeCollector:  nil,
+		errorsCollector:           nil,
+		circuitBreakerCollector:   nil,
+		sourceQueueDepthCollector: nil,
+		targetLatencyCollector:    nil,
+		targetInFlightCollector:   nil,
+		retriesCollector:          nil,
+		rateLimiterWaitCollector:  nil,
 	}
 	if err := e.initPromMetrics(); err != nil {
 		return nil, err
@@ -41,31 +58,69 @@ func (e *Exporter) initPromMetrics() error {
 	e.requestsCollector = newPromCounterMetric(
 		"requests",
 		"count",
-		"counts requests per binding,source and target types",
-		labels...,
+		"counts requests per binding,source and target types and method",
+		requestLabels...,
 	)
 	e.responsesCollector = newPromCounterMetric(
 		"responses",
 		"count",
-		"counts responses per binding,source and target types",
-		labels...,
+		"counts responses per binding,source and target types and method",
+		requestLabels...,
 	)
 	e.requestsVolumeCollector = newPromCounterMetric(
 		"requests",
 		"volume",
-		"sum requests volume per binding,source and target types",
-		labels...,
+		"sum requests volume per binding,source and target types and method",
+		requestLabels...,
 	)
 	e.responsesVolumeCollector = newPromCounterMetric(
 		"responses",
 		"volume",
-		"sum responses volume per binding,source and target types",
-		labels...,
+		"sum responses volume per binding,source and target types and method",
+		requestLabels...,
 	)
 	e.errorsCollector = newPromCounterMetric(
 		"errors",
 		"count",
-		"counts error requests per binding,source and target types",
+		"counts error requests per binding,source and target types, method and error class",
+		errorLabels...,
+	)
+	e.circuitBreakerCollector = newPromGaugeMetric(
+		"circuit_breaker",
+		"state",
+		"circuit breaker state per binding,source and target types (0 - closed, 1 - half-open, 2 - open)",
+		labels...,
+	)
+	e.sourceQueueDepthCollector = newPromGaugeMetric(
+		"source",
+		"queue_depth",
+		"number of received messages waiting for a worker per binding,source and target types",
+		labels...,
+	)
+	e.targetLatencyCollector = newPromHistogramMetric(
+		"target",
+		"latency_seconds",
+		"target execution latency per binding,source and target types and method",
+		prometheus.DefBuckets,
+		requestLabels...,
+	)
+	e.targetInFlightCollector = newPromGaugeMetric(
+		"target",
+		"in_flight",
+		"number of target executions in progress per binding,source and target types",
+		labels...,
+	)
+	e.retriesCollector = newPromCounterMetric(
+		"retry",
+		"attempts",
+		"counts retry attempts per binding,source and target types and method",
+		requestLabels...,
+	)
+	e.rateLimiterWaitCollector = newPromHistogramMetric(
+		"rate_limiter",
+		"wait_seconds",
+		"time requests waited for the rate limiter per binding,source and target types",
+		[]float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 5, 10},
 		labels...,
 	)
 
@@ -89,16 +144,66 @@ func (e *Exporter) initPromMetrics() error {
 	if err != nil {
 		return err
 	}
+	err = prometheus.Register(e.circuitBreakerCollector.metric)
+	if err != nil {
+		return err
+	}
+	err = prometheus.Register(e.sourceQueueDepthCollector.metric)
+	if err != nil {
+		return err
+	}
+	err = prometheus.Register(e.targetLatencyCollector.metric)
+	if err != nil {
+		return err
+	}
+	err = prometheus.Register(e.targetInFlightCollector.metric)
+	if err != nil {
+		return err
+	}
+	err = prometheus.Register(e.retriesCollector.metric)
+	if err != nil {
+		return err
+	}
+	err = prometheus.Register(e.rateLimiterWaitCollector.metric)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
 func (e *Exporter) Report(m *Report) {
-	lbs := m.labels()
+	lbs := m.requestLabels()
 	e.requestsCollector.add(m.RequestCount, lbs)
 	e.requestsVolumeCollector.add(m.RequestVolume, lbs)
 	e.responsesCollector.add(m.ResponseCount, lbs)
 	e.responsesVolumeCollector.add(m.ResponseVolume, lbs)
-	e.errorsCollector.add(m.ErrorsCount, lbs)
+	if m.ErrorClass != "" {
+		e.errorsCollector.add(m.ErrorsCount, m.errorLabels())
+	}
 	e.Store.Add(m)
 }
+
+func (e *Exporter) ReportCircuitBreakerState(m *Report, state float64) {
+	e.circuitBreakerCollector.set(state, m.labels())
+}
+
+func (e *Exporter) ReportSourceQueueDepth(m *Report, depth float64) {
+	e.sourceQueueDepthCollector.set(depth, m.labels())
+}
+
+func (e *Exporter) ReportTargetLatency(m *Report, latency time.Duration) {
+	e.targetLatencyCollector.observe(latency.Seconds(), m.requestLabels())
+}
+
+func (e *Exporter) ReportTargetInFlight(m *Report, delta float64) {
+	e.targetInFlightCollector.add(delta, m.labels())
+}
+
+func (e *Exporter) ReportRetries(m *Report, retries float64) {
+	e.retriesCollector.add(retries, m.requestLabels())
+}
+
+func (e *Exporter) ReportRateLimiterWait(m *Report, wait time.Duration) {
+	e.rateLimiterWaitCollector.observe(wait.Seconds(), m.labels())
+}
@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExporter_Report(t *testing.T) {
+	e, err := NewExporter()
+	require.NoError(t, err)
+	report := &Report{
+		Key:          "b-1-sk-tk",
+		Binding:      "b-1",
+		SourceKind:   "sk",
+		TargetKind:   "tk",
+		RequestCount: 1,
+		ErrorsCount:  1,
+		Method:       "get",
+		ErrorClass:   "permanent",
+	}
+	e.Report(report)
+	e.ReportRetries(report, 2)
+	e.ReportTargetInFlight(report, 1)
+	e.ReportTargetLatency(report, 100*time.Millisecond)
+	e.ReportRateLimiterWait(report, 10*time.Millisecond)
+
+	require.Equal(t, float64(1), testutil.ToFloat64(e.requestsCollector.metric.With(report.requestLabels())))
+	require.Equal(t, float64(1), testutil.ToFloat64(e.errorsCollector.metric.With(report.errorLabels())))
+	require.Equal(t, float64(2), testutil.ToFloat64(e.retriesCollector.metric.With(report.requestLabels())))
+	require.Equal(t, float64(1), testutil.ToFloat64(e.targetInFlightCollector.metric.With(report.labels())))
+	require.Equal(t, 1, testutil.CollectAndCount(e.targetLatencyCollector.metric))
+	require.Equal(t, 1, testutil.CollectAndCount(e.rateLimiterWaitCollector.metric))
+
+	e.Report(&Report{
+		Key:          "b-2-sk-tk",
+		Binding:      "b-2",
+		SourceKind:   "sk",
+		TargetKind:   "tk",
+		RequestCount: 1,
+		Method:       "get",
+	})
+	require.Equal(t, 1, testutil.CollectAndCount(e.errorsCollector.metric), "successful reports should not add error series")
+
+	stored := e.Store.Get(report.Key)
+	require.NotNil(t, stored)
+	require.Equal(t, float64(1), stored.ErrorsCount)
+}
@@ -0,0 +1,31 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type promGaugeMetric struct {
+	metric *prometheus.GaugeVec
+}
+
+func newPromGaugeMetric(subsystem, name, help string, labels ...string) *promGaugeMetric {
+	opts := prometheus.GaugeOpts{
+		Namespace:   "kubemq_targets",
+		Subsystem:   subsystem,
+		Name:        name,
+		Help:        help,
+		ConstLabels: nil,
+	}
+
+	g := &promGaugeMetric{}
+	g.metric = prometheus.NewGaugeVec(opts, labels)
+	return g
+}
+
+func (g *promGaugeMetric) set(value float64, labels prometheus.Labels) {
+	g.metric.With(labels).Set(value)
+}
+
+func (g *promGaugeMetric) add(value float64, labels prometheus.Labels) {
+	g.metric.With(labels).Add(value)
+}
@@ -0,0 +1,28 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type promHistogramMetric struct {
+	metric *prometheus.HistogramVec
+}
+
+func newPromHistogramMetric(subsystem, name, help string, buckets []float64, labels ...string) *promHistogramMetric {
+	opts := prometheus.HistogramOpts{
+		Namespace:   "kubemq_targets",
+		Subsystem:   subsystem,
+		Name:        name,
+		Help:        help,
+		ConstLabels: nil,
+		Buckets:     buckets,
+	}
+
+	h := &promHistogramMetric{}
+	h.metric = prometheus.NewHistogramVec(opts, labels)
+	return h
+}
+
+func (h *promHistogramMetric) observe(value float64, labels prometheus.Labels) {
+	h.metric.With(labels).Observe(value)
+}
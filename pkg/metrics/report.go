@@ -14,6 +14,8 @@ type Report struct {
 	ResponseCount  float64 `json:"response_count"`
 	ResponseVolume float64 `json:"response_volume"`
 	ErrorsCount    float64 `json:"errors_count"`
+	Method         string  `json:"-"`
+	ErrorClass     string  `json:"-"`
 }
 
 func (m *Report) labels() prometheus.Labels {
@@ -24,6 +26,18 @@ func (m *Report) labels() prometheus.Labels {
 	}
 }
 
+func (m *Report) requestLabels() prometheus.Labels {
+	lbs := m.labels()
+	lbs["method"] = m.Method
+	return lbs
+}
+
+func (m *Report) errorLabels() prometheus.Labels {
+	lbs := m.requestLabels()
+	lbs["class"] = m.ErrorClass
+	return lbs
+}
+
 func (m *Report) Clone() *Report {
 	return &Report{
 		Key:            m.Key,
@@ -35,5 +49,7 @@ func (m *Report) Clone() *Report {
 		ResponseCount:  m.ResponseCount,
 		ResponseVolume: m.ResponseVolume,
 		ErrorsCount:    m.ErrorsCount,
+		Method:         m.Method,
+		ErrorClass:     m.ErrorClass,
 	}
 }
@@ -0,0 +1,161 @@
+package redact
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+// Mask replaces redacted values
+const Mask = "******"
+
+// secretNames are the credential property names used by the connectors
+var secretNames = map[string]bool{
+	"password":           true,
+	"token":              true,
+	"connection":         true,
+	"credentials":        true,
+	"aws_key":            true,
+	"cert_key":           true,
+	"client_key":         true,
+	"auth_key":           true,
+	"db_url":             true,
+	"authorization":      true,
+	"shared_access_key":  true,
+	"storage_access_key": true,
+}
+
+var secretSuffixes = []string{"_password", "_secret", "_secret_key", "_secret_access_key", "_token", "_private_key", "_credentials"}
+
+// urlCredentials matches the scheme and userinfo of the urls in a text
+var urlCredentials = regexp.MustCompile(`[a-zA-Z][a-zA-Z0-9+.-]*://[^/?#\s]+@`)
+
+// secretReference matches a value made only of a ${scheme:key} secret reference, which is safe to show
+var secretReference = regexp.MustCompile(`^\$\{[a-z0-9]+:[^}]+\}$`)
+
+// IsSecretName returns true when a property or field name holds a credential
+func IsSecretName(name string) bool {
+	name = strings.ToLower(name)
+	if secretNames[name] {
+		return true
+	}
+	for _, suffix := range secretSuffixes {
+		if strings.HasSuffix(name, suffix) || name == strings.TrimPrefix(suffix, "_") {
+			return true
+		}
+	}
+	return false
+}
+
+// Properties returns a copy of properties with the values of the keys matched by isSecret masked,
+// and the credentials of the other url values masked
+func Properties(properties map[string]string, isSecret func(key string) bool) map[string]string {
+	if properties == nil {
+		return nil
+	}
+	redacted := make(map[string]string, len(properties))
+	for key, value := range properties {
+		switch {
+		case value == "" || secretReference.MatchString(value):
+		case isSecret(key):
+			value = Mask
+		default:
+			value = URL(value)
+		}
+		redacted[key] = value
+	}
+	return redacted
+}
+
+// URL returns value with the userinfo password masked, or the whole userinfo when it has no password,
+// values which are not urls with userinfo are returned as is
+func URL(value string) string {
+	scheme := strings.Index(value, "://")
+	if scheme < 0 {
+		return value
+	}
+	start := scheme + len("://")
+	authority := value[start:]
+	if end := strings.IndexAny(authority, "/?#"); end >= 0 {
+		authority = authority[:end]
+	}
+	at := strings.LastIndex(authority, "@")
+	if at < 0 {
+		return value
+	}
+	userinfo := Mask
+	if colon := strings.Index(authority[:at], ":"); colon >= 0 {
+		userinfo = authority[:colon+1] + Mask
+	}
+	return value[:start] + userinfo + value[start+at:]
+}
+
+// Fields is a set of field names whose values are masked in metadata and json data
+type Fields map[string]bool
+
+// NewFields returns the set of the comma separated field names
+func NewFields(list string) Fields {
+	fields := Fields{}
+	for _, name := range strings.Split(list, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			fields[strings.ToLower(name)] = true
+		}
+	}
+	return fields
+}
+
+func (f Fields) has(name string) bool {
+	return f[strings.ToLower(name)]
+}
+
+// Metadata returns a copy of metadata with the fields values masked
+func (f Fields) Metadata(metadata map[string]string) map[string]string {
+	if len(f) == 0 {
+		return metadata
+	}
+	return Properties(metadata, f.has)
+}
+
+// JSON returns data with the values of the fields masked at any depth, data which is not a json object or array is returned as is
+func (f Fields) JSON(data []byte) []byte {
+	if len(f) == 0 || len(data) == 0 {
+		return data
+	}
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return data
+	}
+	switch value.(type) {
+	case map[string]interface{}, []interface{}:
+	default:
+		return data
+	}
+	redacted, err := json.Marshal(f.value(value))
+	if err != nil {
+		return data
+	}
+	return redacted
+}
+
+func (f Fields) value(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if f.has(key) {
+				v[key] = Mask
+			} else {
+				v[key] = f.value(item)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = f.value(item)
+		}
+	}
+	return value
+}
+
+// Text returns s with the credentials of the urls it contains masked as URL does, as in error messages
+func Text(s string) string {
+	return urlCredentials.ReplaceAllStringFunc(s, URL)
+}
@@ -22,9 +22,14 @@ type Config struct {
 	onRetry       OnRetryFunc
 	retryIf       RetryIfFunc
 	delayType     DelayTypeFunc
+	retryAfter    RetryAfterFunc
 	lastErrorOnly bool
 }
 
+// Function signature of RetryAfter function
+// returns the minimum delay before retrying after err
+type RetryAfterFunc func(err error) time.Duration
+
 // Option represents an option for retry.
 type Option func(*Config)
 
@@ -75,6 +80,14 @@ func DelayType(delayType DelayTypeFunc) Option {
 	}
 }
 
+// RetryAfter sets the minimum delay after a failed attempt from its error, as the retry hint of a throttled error.
+// The delay is the longest of the delay type and the retry after delays, it is not bounded by MaxDelay
+func RetryAfter(retryAfter RetryAfterFunc) Option {
+	return func(c *Config) {
+		c.retryAfter = retryAfter
+	}
+}
+
 // BackOffDelay is a DelayType which increases delay between consecutive retries
 func BackOffDelay(n uint, config *Config) time.Duration {
 	return config.delay * (1 << n)
@@ -70,6 +70,11 @@ func Do(retryableFunc RetryableFunc, opts ...Option) error {
 			if config.maxDelay > 0 && delayTime > config.maxDelay {
 				delayTime = config.maxDelay
 			}
+			if config.retryAfter != nil {
+				if retryAfter := config.retryAfter(err); retryAfter > delayTime {
+					delayTime = retryAfter
+				}
+			}
 			time.Sleep(delayTime)
 		} else {
 			return nil
@@ -121,6 +126,16 @@ func (e Error) WrappedErrors() []error {
 	return e
 }
 
+// Unwrap returns the last error, so errors.Is and errors.As match the final attempt failure
+func (e Error) Unwrap() error {
+	for i := len(e) - 1; i >= 0; i-- {
+		if e[i] != nil {
+			return e[i]
+		}
+	}
+	return nil
+}
+
 type unrecoverableError struct {
 	error
 }
@@ -0,0 +1,86 @@
+// Package sqlerror classifies the errors returned by the sql drivers of the stores targets
+package sqlerror
+
+import (
+	"errors"
+
+	mssql "github.com/denisenkom/go-mssqldb"
+	"github.com/go-sql-driver/mysql"
+	"github.com/kubemq-io/kubemq-targets/types"
+	"github.com/lib/pq"
+)
+
+// postgresPermanentClasses are the postgres sqlstate classes of statements which fail again on retry
+var postgresPermanentClasses = map[pq.ErrorClass]bool{
+	"0A": true, // feature not supported
+	"22": true, // data exception
+	"23": true, // integrity constraint violation
+	"26": true, // invalid sql statement name
+	"3F": true, // invalid schema name
+	"42": true, // syntax error or access rule violation
+}
+
+// mysqlPermanentNumbers are the mysql error numbers of statements which fail again on retry
+var mysqlPermanentNumbers = map[uint16]bool{
+	1048: true, // column cannot be null
+	1054: true, // unknown column
+	1062: true, // duplicate entry
+	1064: true, // syntax error
+	1136: true, // column count does not match value count
+	1146: true, // table does not exist
+	1216: true, // foreign key constraint fails on child row
+	1217: true, // foreign key constraint fails on parent row
+	1264: true, // out of range value
+	1292: true, // incorrect value
+	1366: true, // incorrect value for column
+	1406: true, // data too long
+	1451: true, // foreign key constraint fails on parent row
+	1452: true, // foreign key constraint fails on child row
+}
+
+// mssqlPermanentNumbers are the sql server error numbers of statements which fail again on retry
+var mssqlPermanentNumbers = map[int32]bool{
+	102:  true, // incorrect syntax
+	156:  true, // incorrect syntax near keyword
+	207:  true, // invalid column name
+	208:  true, // invalid object name
+	245:  true, // conversion failed
+	515:  true, // cannot insert null
+	547:  true, // constraint conflict
+	2601: true, // duplicate key row
+	2627: true, // unique constraint violation
+	8152: true, // string or binary data would be truncated
+}
+
+// Classify returns err as a permanent error when the database rejected the statement itself, as a syntax error,
+// an unknown table or column or a constraint violation. Other errors, and errors already classified, are returned as is
+func Classify(err error) error {
+	if err == nil {
+		return nil
+	}
+	var classified *types.Error
+	if errors.As(err, &classified) {
+		return err
+	}
+	if IsPermanent(err) {
+		return types.NewPermanentError(err)
+	}
+	return err
+}
+
+// IsPermanent reports whether err is a driver error of a statement which fails again on retry
+func IsPermanent(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return postgresPermanentClasses[pqErr.Code.Class()]
+	}
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlPermanentNumbers[mysqlErr.Number]
+	}
+	var mssqlErr mssql.Error
+	if errors.As(err, &mssqlErr) {
+		return mssqlPermanentNumbers[mssqlErr.Number]
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package sqlerror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	mssql "github.com/denisenkom/go-mssqldb"
+	"github.com/go-sql-driver/mysql"
+	"github.com/kubemq-io/kubemq-targets/types"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		wantClass types.ErrorClass
+	}{
+		{
+			name:      "postgres syntax error",
+			err:       &pq.Error{Code: "42601"},
+			wantClass: types.ErrorClassPermanent,
+		},
+		{
+			name:      "postgres unique violation wrapped",
+			err:       fmt.Errorf("error on statement 0, %w", &pq.Error{Code: "23505"}),
+			wantClass: types.ErrorClassPermanent,
+		},
+		{
+			name:      "postgres serialization failure",
+			err:       &pq.Error{Code: "40001"},
+			wantClass: types.ErrorClassTransient,
+		},
+		{
+			name:      "mysql syntax error",
+			err:       &mysql.MySQLError{Number: 1064},
+			wantClass: types.ErrorClassPermanent,
+		},
+		{
+			name:      "mysql deadlock",
+			err:       &mysql.MySQLError{Number: 1213},
+			wantClass: types.ErrorClassTransient,
+		},
+		{
+			name:      "mssql invalid object",
+			err:       mssql.Error{Number: 208},
+			wantClass: types.ErrorClassPermanent,
+		},
+		{
+			name:      "mssql deadlock",
+			err:       mssql.Error{Number: 1205},
+			wantClass: types.ErrorClassTransient,
+		},
+		{
+			name:      "connection error",
+			err:       errors.New("connection refused"),
+			wantClass: types.ErrorClassTransient,
+		},
+		{
+			name:      "already classified",
+			err:       types.NewThrottledError(&pq.Error{Code: "42601"}, 0),
+			wantClass: types.ErrorClassThrottled,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Classify(tt.err)
+			require.Error(t, err)
+			require.Equal(t, tt.wantClass, types.ErrorClassOf(err))
+			require.Equal(t, tt.err.Error(), err.Error())
+		})
+	}
+	require.NoError(t, Classify(nil))
+}
@@ -0,0 +1,128 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/kubemq-io/kubemq-targets/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/kubemq-io/kubemq-targets"
+
+// Span attribute keys set on binding spans
+const (
+	BindingKey    = attribute.Key("kubemq.binding")
+	SourceKindKey = attribute.Key("kubemq.source.kind")
+	ChannelKey    = attribute.Key("kubemq.channel")
+	TargetKindKey = attribute.Key("kubemq.target.kind")
+	TargetNameKey = attribute.Key("kubemq.target.name")
+	MethodKey     = attribute.Key("kubemq.target.method")
+	StageKey      = attribute.Key("kubemq.middleware.stage")
+	MessageIdKey  = attribute.Key("kubemq.message.id")
+)
+
+func init() {
+	// trace context is propagated even when tracing is disabled, so upstream traces continue through the binding
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+}
+
+// Provider owns the tracer provider installed as the global tracer provider
+type Provider struct {
+	tp *sdktrace.TracerProvider
+}
+
+// NewProvider installs an OTLP/HTTP exporting tracer provider, a disabled config keeps the no-op global provider
+func NewProvider(ctx context.Context, cfg config.TracingConfig) (*Provider, error) {
+	if !cfg.Enabled {
+		return &Provider{}, nil
+	}
+	opts := []otlptracehttp.Option{
+		otlptracehttp.WithEndpoint(cfg.Endpoint),
+	}
+	if cfg.UrlPath != "" {
+		opts = append(opts, otlptracehttp.WithURLPath(cfg.UrlPath))
+	}
+	if cfg.Insecure {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	if len(cfg.Headers) > 0 {
+		opts = append(opts, otlptracehttp.WithHeaders(cfg.Headers))
+	}
+	exporter, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(cfg.ServiceName))),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(tp)
+	return &Provider{tp: tp}, nil
+}
+
+// NewInMemoryProvider installs a tracer provider recording all spans synchronously into an in-memory exporter
+func NewInMemoryProvider() (*Provider, *tracetest.InMemoryExporter) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSyncer(exporter),
+		sdktrace.WithSampler(sdktrace.AlwaysSample()),
+	)
+	otel.SetTracerProvider(tp)
+	return &Provider{tp: tp}, exporter
+}
+
+// Shutdown flushes pending spans and restores the no-op global provider
+func (p *Provider) Shutdown(ctx context.Context) error {
+	if p == nil || p.tp == nil {
+		return nil
+	}
+	otel.SetTracerProvider(trace.NewNoopTracerProvider())
+	return p.tp.Shutdown(ctx)
+}
+
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+func StartSpan(ctx context.Context, name string, kind trace.SpanKind, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithSpanKind(kind), trace.WithAttributes(attrs...))
+}
+
+// EndSpan records err, if any, on span and ends it
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Extract returns ctx with the W3C trace context found in carrier, KubeMQ message tags for example
+func Extract(ctx context.Context, carrier map[string]string) context.Context {
+	if len(carrier) == 0 {
+		return ctx
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(carrier))
+}
+
+// Inject returns the W3C trace context headers of ctx, empty when ctx carries no valid span
+func Inject(ctx context.Context) map[string]string {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	return carrier
+}
+
+// StartSource starts the consumer span of a message received by a source, continuing the trace context found in its tags
+func StartSource(ctx context.Context, kind, channel, id string, tags map[string]string) (context.Context, trace.Span) {
+	return StartSpan(Extract(ctx, tags), kind+" receive", trace.SpanKindConsumer, SourceKindKey.String(kind), ChannelKey.String(channel), MessageIdKey.String(id))
+}
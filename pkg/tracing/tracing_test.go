@@ -0,0 +1,42 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kubemq-io/kubemq-targets/config"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracing_ExtractInject(t *testing.T) {
+	provider, exporter := NewInMemoryProvider()
+	defer func() {
+		_ = provider.Shutdown(context.Background())
+	}()
+	tags := map[string]string{
+		"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"some-tag":    "some-value",
+	}
+	ctx, span := StartSpan(Extract(context.Background(), tags), "source.events", trace.SpanKindConsumer, ChannelKey.String("some-channel"))
+	headers := Inject(ctx)
+	EndSpan(span, errors.New("some-error"))
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext.TraceID().String())
+	require.Equal(t, "00f067aa0ba902b7", spans[0].Parent.SpanID().String())
+	require.Equal(t, codes.Error, spans[0].Status.Code)
+	require.Contains(t, headers["traceparent"], "4bf92f3577b34da6a3ce929d0e0e4736")
+	require.Contains(t, headers["traceparent"], spans[0].SpanContext.SpanID().String())
+}
+
+func TestTracing_NoTraceContext(t *testing.T) {
+	ctx := Extract(context.Background(), nil)
+	require.Empty(t, Inject(ctx))
+	provider, err := NewProvider(ctx, config.TracingConfig{})
+	require.NoError(t, err)
+	require.NoError(t, provider.Shutdown(ctx))
+}
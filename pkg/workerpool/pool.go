@@ -0,0 +1,141 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"sync"
+	"sync/atomic"
+)
+
+var ErrPoolClosed = errors.New("worker pool is closed")
+
+// Pool runs submitted tasks on a fixed number of workers. Submit blocks while all workers are busy and
+// their queues are full, pushing back on the caller. In ordered mode tasks with the same key always run
+// on the same worker, one after the other, in submission order.
+// A pool with zero workers runs each task in its own goroutine.
+type Pool struct {
+	depth    int64
+	workers  int
+	ordered  bool
+	queues   []chan func()
+	onDepth  func(depth int)
+	done     chan struct{}
+	wg       sync.WaitGroup
+	once     sync.Once
+	running  sync.WaitGroup
+	mu       sync.Mutex
+	isClosed bool
+}
+
+func New(workers int, ordered bool) *Pool {
+	p := &Pool{
+		workers: workers,
+		ordered: ordered,
+		done:    make(chan struct{}),
+	}
+	switch {
+	case workers == 0:
+	case ordered:
+		for i := 0; i < workers; i++ {
+			p.queues = append(p.queues, make(chan func(), 1))
+		}
+	default:
+		p.queues = append(p.queues, make(chan func(), workers))
+	}
+	return p
+}
+
+// SetDepthReporter sets a callback invoked on every queue depth change, must be called before Start
+func (p *Pool) SetDepthReporter(onDepth func(depth int)) {
+	p.onDepth = onDepth
+}
+
+func (p *Pool) Start() {
+	for i := 0; i < p.workers; i++ {
+		queue := p.queues[0]
+		if p.ordered {
+			queue = p.queues[i]
+		}
+		p.wg.Add(1)
+		go p.run(queue)
+	}
+}
+
+func (p *Pool) run(queue chan func()) {
+	defer p.wg.Done()
+	for {
+		select {
+		case task := <-queue:
+			p.addDepth(-1)
+			task()
+		case <-p.done:
+			return
+		}
+	}
+}
+
+func (p *Pool) addDepth(delta int64) {
+	depth := atomic.AddInt64(&p.depth, delta)
+	if p.onDepth != nil {
+		p.onDepth(int(depth))
+	}
+}
+
+// Depth returns the number of submitted tasks waiting for a worker
+func (p *Pool) Depth() int {
+	return int(atomic.LoadInt64(&p.depth))
+}
+
+func (p *Pool) queue(key string) chan func() {
+	if !p.ordered {
+		return p.queues[0]
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return p.queues[h.Sum32()%uint32(len(p.queues))]
+}
+
+// Submit queues task for execution, blocking until a worker can accept it, the context is canceled or the pool is closed
+func (p *Pool) Submit(ctx context.Context, key string, task func()) error {
+	p.mu.Lock()
+	if p.isClosed {
+		p.mu.Unlock()
+		return ErrPoolClosed
+	}
+	p.running.Add(1)
+	p.mu.Unlock()
+	wrapped := func() {
+		defer p.running.Done()
+		task()
+	}
+	if p.workers == 0 {
+		go wrapped()
+		return nil
+	}
+	p.addDepth(1)
+	select {
+	case p.queue(key) <- wrapped:
+		return nil
+	case <-ctx.Done():
+		p.addDepth(-1)
+		p.running.Done()
+		return ctx.Err()
+	case <-p.done:
+		p.addDepth(-1)
+		p.running.Done()
+		return ErrPoolClosed
+	}
+}
+
+// Close stops accepting new tasks and waits for all accepted tasks to complete
+func (p *Pool) Close() {
+	p.once.Do(func() {
+		p.mu.Lock()
+		p.isClosed = true
+		p.mu.Unlock()
+		p.running.Wait()
+		close(p.done)
+		p.wg.Wait()
+	})
+}
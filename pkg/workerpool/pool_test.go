@@ -0,0 +1,132 @@
+package workerpool
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPool_MaxConcurrency(t *testing.T) {
+	tests := []struct {
+		name    string
+		workers int
+		ordered bool
+		tasks   int
+	}{
+		{
+			name:    "unordered",
+			workers: 3,
+			ordered: false,
+			tasks:   20,
+		},
+		{
+			name:    "ordered",
+			workers: 3,
+			ordered: true,
+			tasks:   20,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			p := New(tt.workers, tt.ordered)
+			p.Start()
+			var current, max, executed int64
+			for i := 0; i < tt.tasks; i++ {
+				err := p.Submit(ctx, fmt.Sprintf("key-%d", i), func() {
+					n := atomic.AddInt64(&current, 1)
+					for {
+						m := atomic.LoadInt64(&max)
+						if n <= m || atomic.CompareAndSwapInt64(&max, m, n) {
+							break
+						}
+					}
+					time.Sleep(10 * time.Millisecond)
+					atomic.AddInt64(&current, -1)
+					atomic.AddInt64(&executed, 1)
+				})
+				require.NoError(t, err)
+			}
+			p.Close()
+			require.EqualValues(t, tt.tasks, executed)
+			require.LessOrEqual(t, max, int64(tt.workers))
+			require.Equal(t, 0, p.Depth())
+		})
+	}
+}
+
+func TestPool_Ordered(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	p := New(4, true)
+	p.Start()
+	mu := sync.Mutex{}
+	results := map[string][]int{}
+	for i := 0; i < 50; i++ {
+		key := fmt.Sprintf("key-%d", i%5)
+		seq := i
+		err := p.Submit(ctx, key, func() {
+			time.Sleep(time.Millisecond)
+			mu.Lock()
+			defer mu.Unlock()
+			results[key] = append(results[key], seq)
+		})
+		require.NoError(t, err)
+	}
+	p.Close()
+	require.Len(t, results, 5)
+	for key, list := range results {
+		require.Len(t, list, 10, key)
+		for i := 1; i < len(list); i++ {
+			require.Less(t, list[i-1], list[i], key)
+		}
+	}
+}
+
+func TestPool_Backpressure(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	p := New(1, false)
+	var maxDepth int64
+	p.SetDepthReporter(func(depth int) {
+		if int64(depth) > atomic.LoadInt64(&maxDepth) {
+			atomic.StoreInt64(&maxDepth, int64(depth))
+		}
+	})
+	p.Start()
+	block := make(chan struct{})
+	require.NoError(t, p.Submit(ctx, "", func() { <-block }))
+	time.Sleep(50 * time.Millisecond)
+	require.NoError(t, p.Submit(ctx, "", func() {}))
+	require.Equal(t, 1, p.Depth())
+	submitCtx, submitCancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer submitCancel()
+	err := p.Submit(submitCtx, "", func() {})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	close(block)
+	p.Close()
+	require.EqualValues(t, 2, atomic.LoadInt64(&maxDepth))
+	require.Equal(t, 0, p.Depth())
+	require.ErrorIs(t, p.Submit(ctx, "", func() {}), ErrPoolClosed)
+}
+
+func TestPool_Unbounded(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	p := New(0, false)
+	p.Start()
+	var executed int64
+	for i := 0; i < 10; i++ {
+		require.NoError(t, p.Submit(ctx, "", func() {
+			atomic.AddInt64(&executed, 1)
+		}))
+	}
+	p.Close()
+	require.EqualValues(t, 10, executed)
+}
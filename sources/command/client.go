@@ -10,7 +10,9 @@ import (
 	"github.com/kubemq-io/kubemq-targets/config"
 	"github.com/kubemq-io/kubemq-targets/middleware"
 	"github.com/kubemq-io/kubemq-targets/pkg/logger"
+	"github.com/kubemq-io/kubemq-targets/pkg/tracing"
 	"github.com/kubemq-io/kubemq-targets/pkg/uuid"
+	"github.com/kubemq-io/kubemq-targets/pkg/workerpool"
 	"github.com/kubemq-io/kubemq-targets/types"
 )
 
@@ -21,6 +23,7 @@ type Client struct {
 	clients []*kubemq.Client
 	log     *logger.Logger
 	target  middleware.Middleware
+	pool    *workerpool.Pool
 }
 
 func New() *Client {
@@ -60,9 +63,14 @@ func (c *Client) Init(ctx context.Context, cfg config.Spec, log *logger.Logger)
 		}
 		c.clients = append(c.clients, client)
 	}
+	c.pool = workerpool.New(c.opts.maxConcurrency, c.opts.ordered)
 	return nil
 }
 
+func (c *Client) SetQueueDepthReporter(report func(depth int)) {
+	c.pool.SetDepthReporter(report)
+}
+
 func (c *Client) Start(ctx context.Context, target middleware.Middleware) error {
 	if target == nil {
 		return errInvalidTarget
@@ -72,6 +80,7 @@ func (c *Client) Start(ctx context.Context, target middleware.Middleware) error
 	if c.opts.sources > 1 && c.opts.group == "" {
 		c.opts.group = uuid.New().String()
 	}
+	c.pool.Start()
 
 	for i := 0; i < len(c.clients); i++ {
 		err := c.runClient(ctx, c.clients[i])
@@ -92,19 +101,27 @@ func (c *Client) runClient(ctx context.Context, client *kubemq.Client) error {
 		for {
 			select {
 			case command := <-commandCh:
-				go func(q *kubemq.CommandReceive) {
+				err := c.pool.Submit(ctx, command.Tags[c.opts.orderedKey], func() {
 					cmdResponse := client.R().
 						SetRequestId(command.Id).
 						SetResponseTo(command.ResponseTo)
-					_, err := c.processCommand(ctx, command)
+					resp, err := c.processCommand(ctx, command)
 					if err != nil {
 						cmdResponse.SetError(err)
+						resp = types.NewResponse()
+					}
+					if c.opts.propagateMetadata {
+						cmdResponse.SetTags(resp.MergeTags(command.Tags).Tags())
 					}
 					err = cmdResponse.Send(ctx)
 					if err != nil {
 						c.log.Errorf("error sending command response %s", err.Error())
 					}
-				}(command)
+				})
+				if err != nil {
+					c.log.Errorf("error processing command, %s", err.Error())
+					return
+				}
 
 			case err := <-errCh:
 				c.log.Errorf("error received from kuebmq server, %s", err.Error())
@@ -129,7 +146,18 @@ func (c *Client) processCommand(ctx context.Context, command *kubemq.CommandRece
 			return nil, fmt.Errorf("invalid request format, %w", err)
 		}
 	}
+	if c.opts.propagateMetadata {
+		req.SetMessageContext(&types.MessageContext{
+			Id:       command.Id,
+			Channel:  command.Channel,
+			ClientId: command.ClientId,
+			Metadata: command.Metadata,
+			Tags:     command.Tags,
+		})
+	}
+	ctx, span := tracing.StartSource(ctx, "kubemq.command", command.Channel, command.Id, command.Tags)
 	resp, err := c.target.Do(ctx, req)
+	tracing.EndSpan(span, err)
 	if err != nil {
 		return nil, err
 	}
@@ -137,6 +165,7 @@ func (c *Client) processCommand(ctx context.Context, command *kubemq.CommandRece
 }
 
 func (c *Client) Stop() error {
+	c.pool.Close()
 	for _, client := range c.clients {
 		_ = client.Close()
 	}
@@ -38,6 +38,15 @@ func Connector() *common.Connector {
 				SetMust(false).
 				SetDefault("false"),
 		).
+		AddProperty(
+			common.NewProperty().
+				SetKind("bool").
+				SetName("propagate_metadata").
+				SetTitle("Propagate Message Metadata").
+				SetDescription("Set message id, channel, tags and attributes in request metadata").
+				SetMust(false).
+				SetDefault("true"),
+		).
 		AddProperty(
 			common.NewProperty().
 				SetKind("string").
@@ -46,6 +55,33 @@ func Connector() *common.Connector {
 				SetMust(false).
 				SetDefault(""),
 		).
+		AddProperty(
+			common.NewProperty().
+				SetKind("int").
+				SetName("max_concurrency").
+				SetTitle("Max Concurrency").
+				SetDescription("Set how many commands to process concurrently, 0 for unlimited").
+				SetMust(false).
+				SetDefault("0"),
+		).
+		AddProperty(
+			common.NewProperty().
+				SetKind("bool").
+				SetName("ordered").
+				SetTitle("Ordered Processing").
+				SetDescription("Process commands with the same ordered key sequentially").
+				SetMust(false).
+				SetDefault("false"),
+		).
+		AddProperty(
+			common.NewProperty().
+				SetKind("string").
+				SetName("ordered_key").
+				SetTitle("Ordered Key Tag").
+				SetDescription("Set message tag used as ordered processing key").
+				SetMust(false).
+				SetDefault(""),
+		).
 		AddProperty(
 			common.NewProperty().
 				SetKind("string").
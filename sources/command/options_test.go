@@ -127,6 +127,47 @@ func TestOptions_parseOptions(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid options - ordered max concurrency",
+			cfg: config.Spec{
+				Name: "kubemq-rpc",
+				Kind: "",
+				Properties: map[string]string{
+					"address":         "localhost:50000",
+					"channel":         "some-channel",
+					"max_concurrency": "10",
+					"ordered":         "true",
+					"ordered_key":     "customer_id",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid options - bad max concurrency",
+			cfg: config.Spec{
+				Name: "kubemq-rpc",
+				Kind: "",
+				Properties: map[string]string{
+					"address":         "localhost:50000",
+					"channel":         "some-channel",
+					"max_concurrency": "-1",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid options - ordered without max concurrency",
+			cfg: config.Spec{
+				Name: "kubemq-rpc",
+				Kind: "",
+				Properties: map[string]string{
+					"address": "localhost:50000",
+					"channel": "some-channel",
+					"ordered": "true",
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,214 @@
+package events_store
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/kubemq-io/kubemq-go"
+)
+
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+const (
+	checkpointTimeout     = 5 * time.Second
+	checkpointWaitSeconds = 1
+	maxCheckpointMessages = 100
+)
+
+type checkpointStore interface {
+	Load(ctx context.Context) (uint64, bool, error)
+	Save(ctx context.Context, sequence uint64) error
+}
+
+type checkpointRecord struct {
+	Sequence  uint64    `json:"sequence"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+type fileCheckpointStore struct {
+	path string
+}
+
+func newFileCheckpointStore(dir, key string) *fileCheckpointStore {
+	return &fileCheckpointStore{
+		path: filepath.Join(dir, fmt.Sprintf("%s.json", key)),
+	}
+}
+
+func (f *fileCheckpointStore) Load(ctx context.Context) (uint64, bool, error) {
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+	record := &checkpointRecord{}
+	if err := json.Unmarshal(data, record); err != nil {
+		return 0, false, fmt.Errorf("invalid checkpoint file %s, %w", f.path, err)
+	}
+	return record.Sequence, true, nil
+}
+
+func (f *fileCheckpointStore) Save(ctx context.Context, sequence uint64) error {
+	data, err := json.Marshal(&checkpointRecord{
+		Sequence:  sequence,
+		Timestamp: time.Now().UTC(),
+	})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(f.path), 0o755); err != nil {
+		return err
+	}
+	tmp := f.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, f.path)
+}
+
+// kubemqCheckpointStore keeps the checkpoint as a message in a dedicated queue channel, each save sends the new checkpoint
+// and then removes the previous ones, so the channel holds only the last checkpoint
+type kubemqCheckpointStore struct {
+	client   *kubemq.Client
+	channel  string
+	previous int
+}
+
+func newKubemqCheckpointStore(client *kubemq.Client, channel string) *kubemqCheckpointStore {
+	return &kubemqCheckpointStore{
+		client:  client,
+		channel: channel,
+	}
+}
+
+func (k *kubemqCheckpointStore) Load(ctx context.Context) (uint64, bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, checkpointTimeout)
+	defer cancel()
+	resp, err := k.client.NewReceiveQueueMessagesRequest().
+		SetChannel(k.channel).
+		SetMaxNumberOfMessages(maxCheckpointMessages).
+		SetWaitTimeSeconds(checkpointWaitSeconds).
+		SetIsPeak(true).
+		Send(ctx)
+	if err != nil {
+		return 0, false, fmt.Errorf("error on reading checkpoint channel, %w", err)
+	}
+	if resp.IsError {
+		return 0, false, fmt.Errorf("error on reading checkpoint channel, %s", resp.Error)
+	}
+	k.previous = len(resp.Messages)
+	return lastCheckpoint(resp.Messages)
+}
+
+// Save sends the new checkpoint and removes the previous ones ahead of it in the queue, the checkpoints left after a
+// failure to remove them are removed by the next save
+func (k *kubemqCheckpointStore) Save(ctx context.Context, sequence uint64) error {
+	result, err := k.client.NewQueueMessage().
+		SetChannel(k.channel).
+		SetBody([]byte(strconv.FormatUint(sequence, 10))).
+		Send(ctx)
+	if err != nil {
+		return err
+	}
+	if result.IsError {
+		return fmt.Errorf("checkpoint not sent, %s", result.Error)
+	}
+	previous := k.previous
+	k.previous++
+	if previous == 0 {
+		return nil
+	}
+	resp, err := k.client.NewReceiveQueueMessagesRequest().
+		SetChannel(k.channel).
+		SetMaxNumberOfMessages(previous).
+		SetWaitTimeSeconds(checkpointWaitSeconds).
+		Send(ctx)
+	if err != nil {
+		return fmt.Errorf("error on removing previous checkpoints, %w", err)
+	}
+	if resp.IsError {
+		return fmt.Errorf("error on removing previous checkpoints, %s", resp.Error)
+	}
+	k.previous -= len(resp.Messages)
+	return nil
+}
+
+// lastCheckpoint returns the highest checkpoint of the channel messages, checkpoints only increase so it is the last saved
+func lastCheckpoint(messages []*kubemq.QueueMessage) (uint64, bool, error) {
+	var last uint64
+	for _, message := range messages {
+		sequence, err := strconv.ParseUint(string(message.Body), 10, 64)
+		if err != nil {
+			return 0, false, fmt.Errorf("invalid checkpoint value %s, %w", string(message.Body), err)
+		}
+		if sequence > last {
+			last = sequence
+		}
+	}
+	return last, len(messages) > 0, nil
+}
+
+// checkpointTracker tracks in flight events and computes the highest sequence below which all events were processed
+type checkpointTracker struct {
+	sync.Mutex
+	inflight map[uint64]struct{}
+	maxSeen  uint64
+	current  uint64
+	saved    uint64
+}
+
+func newCheckpointTracker(sequence uint64) *checkpointTracker {
+	return &checkpointTracker{
+		inflight: map[uint64]struct{}{},
+		maxSeen:  sequence,
+		current:  sequence,
+		saved:    sequence,
+	}
+}
+
+func (t *checkpointTracker) start(sequence uint64) {
+	t.Lock()
+	defer t.Unlock()
+	t.inflight[sequence] = struct{}{}
+	if sequence > t.maxSeen {
+		t.maxSeen = sequence
+	}
+}
+
+func (t *checkpointTracker) done(sequence uint64) {
+	t.Lock()
+	defer t.Unlock()
+	delete(t.inflight, sequence)
+	checkpoint := t.maxSeen
+	for seq := range t.inflight {
+		if seq-1 < checkpoint {
+			checkpoint = seq - 1
+		}
+	}
+	if checkpoint > t.current {
+		t.current = checkpoint
+	}
+}
+
+// pending returns the checkpoint to persist, if it changed since the last save
+func (t *checkpointTracker) pending() (uint64, bool) {
+	t.Lock()
+	defer t.Unlock()
+	return t.current, t.current != t.saved
+}
+
+func (t *checkpointTracker) setSaved(sequence uint64) {
+	t.Lock()
+	defer t.Unlock()
+	if sequence > t.saved {
+		t.saved = sequence
+	}
+}
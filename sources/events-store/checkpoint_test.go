@@ -0,0 +1,141 @@
+package events_store
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kubemq-io/kubemq-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckpointTracker(t *testing.T) {
+	tests := []struct {
+		name      string
+		initial   uint64
+		started   []uint64
+		done      []uint64
+		want      uint64
+		wantSaved bool
+	}{
+		{
+			name:      "no events",
+			initial:   10,
+			started:   nil,
+			done:      nil,
+			want:      10,
+			wantSaved: false,
+		},
+		{
+			name:      "all events done",
+			initial:   10,
+			started:   []uint64{11, 12, 13},
+			done:      []uint64{13, 11, 12},
+			want:      13,
+			wantSaved: true,
+		},
+		{
+			name:      "lower event in flight",
+			initial:   10,
+			started:   []uint64{11, 12, 13},
+			done:      []uint64{12, 13},
+			want:      10,
+			wantSaved: false,
+		},
+		{
+			name:      "higher event in flight",
+			initial:   10,
+			started:   []uint64{11, 12, 13},
+			done:      []uint64{11, 12},
+			want:      12,
+			wantSaved: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := newCheckpointTracker(tt.initial)
+			for _, seq := range tt.started {
+				tracker.start(seq)
+			}
+			for _, seq := range tt.done {
+				tracker.done(seq)
+			}
+			got, changed := tracker.pending()
+			require.Equal(t, tt.want, got)
+			require.Equal(t, tt.wantSaved, changed)
+			tracker.setSaved(got)
+			_, changed = tracker.pending()
+			require.False(t, changed)
+		})
+	}
+}
+
+func TestFileCheckpointStore(t *testing.T) {
+	ctx := context.Background()
+	dir, err := os.MkdirTemp("", "checkpoints")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	store := newFileCheckpointStore(filepath.Join(dir, "nested"), "binding-1")
+	_, ok, err := store.Load(ctx)
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.NoError(t, store.Save(ctx, 100))
+	require.NoError(t, store.Save(ctx, 120))
+	seq, ok, err := store.Load(ctx)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.EqualValues(t, 120, seq)
+	require.NoError(t, os.WriteFile(store.path, []byte("bad-data"), 0o644))
+	_, _, err = store.Load(ctx)
+	require.Error(t, err)
+}
+
+func TestLastCheckpoint(t *testing.T) {
+	tests := []struct {
+		name    string
+		bodies  []string
+		want    uint64
+		wantOk  bool
+		wantErr bool
+	}{
+		{
+			name:   "empty channel",
+			want:   0,
+			wantOk: false,
+		},
+		{
+			name:   "single checkpoint",
+			bodies: []string{"120"},
+			want:   120,
+			wantOk: true,
+		},
+		{
+			name:   "previous checkpoints not removed",
+			bodies: []string{"100", "110", "120"},
+			want:   120,
+			wantOk: true,
+		},
+		{
+			name:    "invalid checkpoint",
+			bodies:  []string{"100", "bad-data"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var messages []*kubemq.QueueMessage
+			for _, body := range tt.bodies {
+				messages = append(messages, kubemq.NewQueueMessage().SetBody([]byte(body)))
+			}
+			got, ok, err := lastCheckpoint(messages)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantOk, ok)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
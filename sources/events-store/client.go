@@ -4,12 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/kubemq-hub/builder/connector/common"
 	"github.com/kubemq-io/kubemq-go"
 	"github.com/kubemq-io/kubemq-targets/config"
 	"github.com/kubemq-io/kubemq-targets/middleware"
 	"github.com/kubemq-io/kubemq-targets/pkg/logger"
+	"github.com/kubemq-io/kubemq-targets/pkg/tracing"
 	"github.com/kubemq-io/kubemq-targets/pkg/uuid"
 	"github.com/kubemq-io/kubemq-targets/types"
 )
@@ -17,10 +20,14 @@ import (
 var errInvalidTarget = errors.New("invalid target received, cannot be nil")
 
 type Client struct {
-	opts    options
-	clients []*kubemq.Client
-	log     *logger.Logger
-	target  middleware.Middleware
+	opts        options
+	clients     []*kubemq.Client
+	log         *logger.Logger
+	target      middleware.Middleware
+	checkpoints checkpointStore
+	tracker     *checkpointTracker
+	cancel      context.CancelFunc
+	wg          sync.WaitGroup
 }
 
 func New() *Client {
@@ -60,6 +67,12 @@ func (c *Client) Init(ctx context.Context, cfg config.Spec, log *logger.Logger)
 		}
 		c.clients = append(c.clients, client)
 	}
+	switch c.opts.checkpoint {
+	case "file":
+		c.checkpoints = newFileCheckpointStore(c.opts.checkpointDir, c.opts.checkpointKey)
+	case "kubemq":
+		c.checkpoints = newKubemqCheckpointStore(c.clients[0], c.opts.checkpointChannel)
+	}
 	return nil
 }
 
@@ -72,9 +85,26 @@ func (c *Client) Start(ctx context.Context, target middleware.Middleware) error
 	if c.opts.sources > 1 && c.opts.group == "" {
 		c.opts.group = uuid.New().String()
 	}
-
+	var checkpoint uint64
+	var hasCheckpoint bool
+	if c.checkpoints != nil {
+		var err error
+		checkpoint, hasCheckpoint, err = c.checkpoints.Load(ctx)
+		if err != nil {
+			return fmt.Errorf("error loading checkpoint, %w", err)
+		}
+		if hasCheckpoint {
+			c.log.Infof("resuming events store subscription from checkpoint sequence %d", checkpoint)
+		}
+		c.tracker = newCheckpointTracker(checkpoint)
+		var checkpointCtx context.Context
+		checkpointCtx, c.cancel = context.WithCancel(ctx)
+		c.wg.Add(1)
+		go c.runCheckpoints(checkpointCtx)
+	}
+	subOption := c.opts.subscriptionOption(checkpoint, hasCheckpoint)
 	for i := 0; i < len(c.clients); i++ {
-		err := c.runClient(ctx, c.clients[i])
+		err := c.runClient(ctx, c.clients[i], subOption)
 		if err != nil {
 			return fmt.Errorf("error during start of client %d: %s", i, err.Error())
 		}
@@ -82,9 +112,33 @@ func (c *Client) Start(ctx context.Context, target middleware.Middleware) error
 	return nil
 }
 
-func (c *Client) runClient(ctx context.Context, client *kubemq.Client) error {
+func (c *Client) runCheckpoints(ctx context.Context) {
+	defer c.wg.Done()
+	for {
+		select {
+		case <-time.After(c.opts.checkpointInterval):
+			c.saveCheckpoint(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (c *Client) saveCheckpoint(ctx context.Context) {
+	sequence, ok := c.tracker.pending()
+	if !ok {
+		return
+	}
+	if err := c.checkpoints.Save(ctx, sequence); err != nil {
+		c.log.Errorf("error saving checkpoint sequence %d, %s", sequence, err.Error())
+		return
+	}
+	c.tracker.setSaved(sequence)
+}
+
+func (c *Client) runClient(ctx context.Context, client *kubemq.Client, subOption kubemq.SubscriptionOption) error {
 	errCh := make(chan error, 1)
-	eventsCh, err := client.SubscribeToEventsStore(ctx, c.opts.channel, c.opts.group, errCh, kubemq.StartFromNewEvents())
+	eventsCh, err := client.SubscribeToEventsStore(ctx, c.opts.channel, c.opts.group, errCh, subOption)
 	if err != nil {
 		return fmt.Errorf("error on subscribing to events channel, %w", err)
 	}
@@ -92,11 +146,20 @@ func (c *Client) runClient(ctx context.Context, client *kubemq.Client) error {
 		for {
 			select {
 			case event := <-eventsCh:
+				if c.tracker != nil {
+					c.tracker.start(event.Sequence)
+				}
 				go func(event *kubemq.EventStoreReceive) {
+					if c.tracker != nil {
+						defer c.tracker.done(event.Sequence)
+					}
 					resp, err := c.processEventStore(ctx, event)
 					if err != nil {
 						resp = types.NewResponse().SetError(err)
 					}
+					if c.opts.propagateMetadata {
+						resp.MergeTags(event.Tags)
+					}
 					if c.opts.responseChannel != "" {
 						sendRes, errSend := client.SetEventStore(resp.ToEventStore()).SetChannel(c.opts.responseChannel).Send(ctx)
 						if errSend != nil {
@@ -132,7 +195,20 @@ func (c *Client) processEventStore(ctx context.Context, event *kubemq.EventStore
 			return nil, fmt.Errorf("invalid request format, %w", err)
 		}
 	}
+	if c.opts.propagateMetadata {
+		req.SetMessageContext(&types.MessageContext{
+			Id:        event.Id,
+			Channel:   event.Channel,
+			ClientId:  event.ClientId,
+			Metadata:  event.Metadata,
+			Timestamp: event.Timestamp,
+			Sequence:  event.Sequence,
+			Tags:      event.Tags,
+		})
+	}
+	ctx, span := tracing.StartSource(ctx, "kubemq.events-store", event.Channel, event.Id, event.Tags)
 	resp, err := c.target.Do(ctx, req)
+	tracing.EndSpan(span, err)
 	if err != nil {
 		return nil, err
 	}
@@ -140,6 +216,13 @@ func (c *Client) processEventStore(ctx context.Context, event *kubemq.EventStore
 }
 
 func (c *Client) Stop() error {
+	if c.cancel != nil {
+		c.cancel()
+		c.wg.Wait()
+		ctx, cancel := context.WithTimeout(context.Background(), checkpointTimeout)
+		c.saveCheckpoint(ctx)
+		cancel()
+	}
 	for _, client := range c.clients {
 		_ = client.Close()
 	}
@@ -38,6 +38,15 @@ func Connector() *common.Connector {
 				SetMust(false).
 				SetDefault("false"),
 		).
+		AddProperty(
+			common.NewProperty().
+				SetKind("bool").
+				SetName("propagate_metadata").
+				SetTitle("Propagate Message Metadata").
+				SetDescription("Set message id, channel, tags and attributes in request metadata").
+				SetMust(false).
+				SetDefault("true"),
+		).
 		AddProperty(
 			common.NewProperty().
 				SetKind("string").
@@ -108,5 +117,88 @@ func Connector() *common.Connector {
 				SetDescription("Set auto reconnection max reconnects").
 				SetMust(false).
 				SetDefault("0"),
+		).
+		AddProperty(
+			common.NewProperty().
+				SetKind("string").
+				SetName("start_position").
+				SetTitle("Start Position").
+				SetDescription("Set events store subscription start position").
+				SetOptions([]string{"new", "first", "last", "sequence", "time", "time_delta"}).
+				SetMust(false).
+				SetDefault("new"),
+		).
+		AddProperty(
+			common.NewProperty().
+				SetKind("int").
+				SetName("start_sequence").
+				SetTitle("Start Sequence").
+				SetDescription("Set start sequence for sequence start position").
+				SetMust(false).
+				SetDefault("0"),
+		).
+		AddProperty(
+			common.NewProperty().
+				SetKind("string").
+				SetName("start_time").
+				SetTitle("Start Time").
+				SetDescription("Set start time (RFC3339) for time start position").
+				SetMust(false).
+				SetDefault(""),
+		).
+		AddProperty(
+			common.NewProperty().
+				SetKind("int").
+				SetName("start_time_delta_seconds").
+				SetTitle("Start Time Delta (Seconds)").
+				SetDescription("Set start time delta in seconds for time_delta start position").
+				SetMust(false).
+				SetDefault("0"),
+		).
+		AddProperty(
+			common.NewProperty().
+				SetKind("string").
+				SetName("checkpoint").
+				SetTitle("Checkpoint Store").
+				SetDescription("Set where to persist the last processed sequence").
+				SetOptions([]string{"", "file", "kubemq"}).
+				SetMust(false).
+				SetDefault(""),
+		).
+		AddProperty(
+			common.NewProperty().
+				SetKind("string").
+				SetName("checkpoint_key").
+				SetTitle("Checkpoint Key").
+				SetDescription("Set checkpoint key, default to binding name").
+				SetMust(false).
+				SetDefault(""),
+		).
+		AddProperty(
+			common.NewProperty().
+				SetKind("string").
+				SetName("checkpoint_dir").
+				SetTitle("Checkpoint Directory").
+				SetDescription("Set checkpoint files directory").
+				SetMust(false).
+				SetDefault("./checkpoints"),
+		).
+		AddProperty(
+			common.NewProperty().
+				SetKind("string").
+				SetName("checkpoint_channel").
+				SetTitle("Checkpoint Channel").
+				SetDescription("Set queue channel for kubemq checkpoints").
+				SetMust(false).
+				SetDefault(""),
+		).
+		AddProperty(
+			common.NewProperty().
+				SetKind("int").
+				SetName("checkpoint_interval_milliseconds").
+				SetTitle("Checkpoint Interval (Milliseconds)").
+				SetDescription("Set how often to persist checkpoints").
+				SetMust(false).
+				SetDefault("1000"),
 		)
 }
@@ -2,17 +2,37 @@ package events_store
 
 import (
 	"fmt"
+	"math"
 	"time"
 
+	"github.com/kubemq-io/kubemq-go"
 	"github.com/kubemq-io/kubemq-targets/config"
 	"github.com/kubemq-io/kubemq-targets/pkg/uuid"
 )
 
 const (
-	defaultAutoReconnect = true
-	defaultSources       = 1
+	defaultAutoReconnect      = true
+	defaultSources            = 1
+	defaultCheckpointDir      = "./checkpoints"
+	defaultCheckpointInterval = 1000
 )
 
+var startPositions = map[string]string{
+	"":           "new",
+	"new":        "new",
+	"first":      "first",
+	"last":       "last",
+	"sequence":   "sequence",
+	"time":       "time",
+	"time_delta": "time_delta",
+}
+
+var checkpointStores = map[string]string{
+	"":       "",
+	"file":   "file",
+	"kubemq": "kubemq",
+}
+
 type options struct {
 	host                     string
 	port                     int
@@ -26,6 +46,16 @@ type options struct {
 	maxReconnects            int
 	sources                  int
 	doNotParsePayload        bool
+	propagateMetadata        bool
+	startPosition            string
+	startSequence            int
+	startTime                time.Time
+	startTimeDelta           time.Duration
+	checkpoint               string
+	checkpointKey            string
+	checkpointDir            string
+	checkpointChannel        string
+	checkpointInterval       time.Duration
 }
 
 func parseOptions(cfg config.Spec) (options, error) {
@@ -57,5 +87,90 @@ func parseOptions(cfg config.Spec) (options, error) {
 	o.reconnectIntervalSeconds = time.Duration(interval) * time.Second
 	o.maxReconnects = cfg.Properties.ParseInt("max_reconnects", 0)
 	o.doNotParsePayload = cfg.Properties.ParseBool("do_not_parse_payload", false)
+	o.propagateMetadata = cfg.Properties.ParseBool("propagate_metadata", true)
+	if err := o.parseStartPosition(cfg); err != nil {
+		return options{}, err
+	}
+	if err := o.parseCheckpoint(cfg); err != nil {
+		return options{}, err
+	}
 	return o, nil
 }
+
+func (o *options) parseStartPosition(cfg config.Spec) error {
+	var err error
+	o.startPosition, err = cfg.Properties.ParseStringMap("start_position", startPositions)
+	if err != nil {
+		return fmt.Errorf("error parsing start position value, %w", cfg.Properties.GetValidSupportedTypes(startPositions, "start_position"))
+	}
+	switch o.startPosition {
+	case "sequence":
+		o.startSequence, err = cfg.Properties.MustParseIntWithRange("start_sequence", 1, math.MaxInt32)
+		if err != nil {
+			return fmt.Errorf("error parsing start sequence value, %w", err)
+		}
+	case "time":
+		value, err := cfg.Properties.MustParseString("start_time")
+		if err != nil {
+			return fmt.Errorf("error parsing start time value, %w", err)
+		}
+		o.startTime, err = time.Parse(time.RFC3339, value)
+		if err != nil {
+			return fmt.Errorf("error parsing start time value, %w", err)
+		}
+	case "time_delta":
+		delta, err := cfg.Properties.MustParseIntWithRange("start_time_delta_seconds", 1, math.MaxInt32)
+		if err != nil {
+			return fmt.Errorf("error parsing start time delta seconds value, %w", err)
+		}
+		o.startTimeDelta = time.Duration(delta) * time.Second
+	}
+	return nil
+}
+
+func (o *options) parseCheckpoint(cfg config.Spec) error {
+	var err error
+	o.checkpoint, err = cfg.Properties.ParseStringMap("checkpoint", checkpointStores)
+	if err != nil {
+		return fmt.Errorf("error parsing checkpoint value, %w", cfg.Properties.GetValidSupportedTypes(checkpointStores, "checkpoint"))
+	}
+	if o.checkpoint == "" {
+		return nil
+	}
+	defaultKey := cfg.Name
+	if defaultKey == "" {
+		defaultKey = o.channel
+	}
+	o.checkpointKey = cfg.Properties.ParseString("checkpoint_key", defaultKey)
+	o.checkpointDir = cfg.Properties.ParseString("checkpoint_dir", defaultCheckpointDir)
+	o.checkpointChannel = cfg.Properties.ParseString("checkpoint_channel", fmt.Sprintf("kubemq-targets.checkpoints.%s", o.checkpointKey))
+	if o.checkpoint == "kubemq" && o.checkpointChannel == o.channel {
+		return fmt.Errorf("checkpoint channel cannot be the same as the source channel")
+	}
+	interval, err := cfg.Properties.ParseIntWithRange("checkpoint_interval_milliseconds", defaultCheckpointInterval, 1, math.MaxInt32)
+	if err != nil {
+		return fmt.Errorf("error parsing checkpoint interval milliseconds value, %w", err)
+	}
+	o.checkpointInterval = time.Duration(interval) * time.Millisecond
+	return nil
+}
+
+func (o options) subscriptionOption(checkpoint uint64, hasCheckpoint bool) kubemq.SubscriptionOption {
+	if hasCheckpoint {
+		return kubemq.StartFromSequence(int(checkpoint + 1))
+	}
+	switch o.startPosition {
+	case "first":
+		return kubemq.StartFromFirstEvent()
+	case "last":
+		return kubemq.StartFromLastEvent()
+	case "sequence":
+		return kubemq.StartFromSequence(o.startSequence)
+	case "time":
+		return kubemq.StartFromTime(o.startTime)
+	case "time_delta":
+		return kubemq.StartFromTimeDelta(o.startTimeDelta)
+	default:
+		return kubemq.StartFromNewEvents()
+	}
+}
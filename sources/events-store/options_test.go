@@ -128,6 +128,156 @@ func TestOptions_parseOptions(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid options - start from first",
+			cfg: config.Spec{
+				Name: "kubemq-rpc",
+				Kind: "",
+				Properties: map[string]string{
+					"address":        "localhost:50000",
+					"channel":        "some-channel",
+					"start_position": "first",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid options - start from sequence",
+			cfg: config.Spec{
+				Name: "kubemq-rpc",
+				Kind: "",
+				Properties: map[string]string{
+					"address":        "localhost:50000",
+					"channel":        "some-channel",
+					"start_position": "sequence",
+					"start_sequence": "100",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid options - start from sequence without sequence",
+			cfg: config.Spec{
+				Name: "kubemq-rpc",
+				Kind: "",
+				Properties: map[string]string{
+					"address":        "localhost:50000",
+					"channel":        "some-channel",
+					"start_position": "sequence",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid options - start from time",
+			cfg: config.Spec{
+				Name: "kubemq-rpc",
+				Kind: "",
+				Properties: map[string]string{
+					"address":        "localhost:50000",
+					"channel":        "some-channel",
+					"start_position": "time",
+					"start_time":     "2021-01-01T00:00:00Z",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid options - bad start time",
+			cfg: config.Spec{
+				Name: "kubemq-rpc",
+				Kind: "",
+				Properties: map[string]string{
+					"address":        "localhost:50000",
+					"channel":        "some-channel",
+					"start_position": "time",
+					"start_time":     "yesterday",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid options - start from time delta",
+			cfg: config.Spec{
+				Name: "kubemq-rpc",
+				Kind: "",
+				Properties: map[string]string{
+					"address":                  "localhost:50000",
+					"channel":                  "some-channel",
+					"start_position":           "time_delta",
+					"start_time_delta_seconds": "3600",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid options - bad start position",
+			cfg: config.Spec{
+				Name: "kubemq-rpc",
+				Kind: "",
+				Properties: map[string]string{
+					"address":        "localhost:50000",
+					"channel":        "some-channel",
+					"start_position": "middle",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid options - file checkpoint",
+			cfg: config.Spec{
+				Name: "kubemq-rpc",
+				Kind: "",
+				Properties: map[string]string{
+					"address":        "localhost:50000",
+					"channel":        "some-channel",
+					"checkpoint":     "file",
+					"checkpoint_dir": "/tmp/checkpoints",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid options - kubemq checkpoint",
+			cfg: config.Spec{
+				Name: "kubemq-rpc",
+				Kind: "",
+				Properties: map[string]string{
+					"address":            "localhost:50000",
+					"channel":            "some-channel",
+					"checkpoint":         "kubemq",
+					"checkpoint_channel": "checkpoints",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid options - kubemq checkpoint on source channel",
+			cfg: config.Spec{
+				Name: "kubemq-rpc",
+				Kind: "",
+				Properties: map[string]string{
+					"address":            "localhost:50000",
+					"channel":            "some-channel",
+					"checkpoint":         "kubemq",
+					"checkpoint_channel": "some-channel",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid options - bad checkpoint",
+			cfg: config.Spec{
+				Name: "kubemq-rpc",
+				Kind: "",
+				Properties: map[string]string{
+					"address":    "localhost:50000",
+					"channel":    "some-channel",
+					"checkpoint": "redis",
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
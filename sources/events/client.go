@@ -10,7 +10,9 @@ import (
 	"github.com/kubemq-io/kubemq-targets/config"
 	"github.com/kubemq-io/kubemq-targets/middleware"
 	"github.com/kubemq-io/kubemq-targets/pkg/logger"
+	"github.com/kubemq-io/kubemq-targets/pkg/tracing"
 	"github.com/kubemq-io/kubemq-targets/pkg/uuid"
+	"github.com/kubemq-io/kubemq-targets/pkg/workerpool"
 	"github.com/kubemq-io/kubemq-targets/types"
 )
 
@@ -21,6 +23,7 @@ type Client struct {
 	clients []*kubemq.Client
 	log     *logger.Logger
 	target  middleware.Middleware
+	pool    *workerpool.Pool
 }
 
 func New() *Client {
@@ -60,9 +63,14 @@ func (c *Client) Init(ctx context.Context, cfg config.Spec, log *logger.Logger)
 		}
 		c.clients = append(c.clients, client)
 	}
+	c.pool = workerpool.New(c.opts.maxConcurrency, c.opts.ordered)
 	return nil
 }
 
+func (c *Client) SetQueueDepthReporter(report func(depth int)) {
+	c.pool.SetDepthReporter(report)
+}
+
 func (c *Client) Start(ctx context.Context, target middleware.Middleware) error {
 	if target == nil {
 		return errInvalidTarget
@@ -72,6 +80,7 @@ func (c *Client) Start(ctx context.Context, target middleware.Middleware) error
 	if c.opts.sources > 1 && c.opts.group == "" {
 		c.opts.group = uuid.New().String()
 	}
+	c.pool.Start()
 
 	for i := 0; i < len(c.clients); i++ {
 		err := c.runClient(ctx, c.clients[i])
@@ -92,18 +101,25 @@ func (c *Client) runClient(ctx context.Context, client *kubemq.Client) error {
 		for {
 			select {
 			case event := <-eventsCh:
-				go func(event *kubemq.Event) {
+				err := c.pool.Submit(ctx, event.Tags[c.opts.orderedKey], func() {
 					resp, err := c.processEvent(ctx, event)
 					if err != nil {
 						resp = types.NewResponse().SetError(err)
 					}
+					if c.opts.propagateMetadata {
+						resp.MergeTags(event.Tags)
+					}
 					if c.opts.responseChannel != "" {
 						errSend := client.SetEvent(resp.ToEvent()).SetChannel(c.opts.responseChannel).Send(ctx)
 						if errSend != nil {
 							c.log.Errorf("error sending event response %s", errSend.Error())
 						}
 					}
-				}(event)
+				})
+				if err != nil {
+					c.log.Errorf("error processing event, %s", err.Error())
+					return
+				}
 
 			case err := <-errCh:
 				c.log.Errorf("error received from kuebmq server, %s", err.Error())
@@ -127,7 +143,18 @@ func (c *Client) processEvent(ctx context.Context, event *kubemq.Event) (*types.
 			return nil, fmt.Errorf("invalid request format, %w", err)
 		}
 	}
+	if c.opts.propagateMetadata {
+		req.SetMessageContext(&types.MessageContext{
+			Id:       event.Id,
+			Channel:  event.Channel,
+			ClientId: event.ClientId,
+			Metadata: event.Metadata,
+			Tags:     event.Tags,
+		})
+	}
+	ctx, span := tracing.StartSource(ctx, "kubemq.events", event.Channel, event.Id, event.Tags)
 	resp, err := c.target.Do(ctx, req)
+	tracing.EndSpan(span, err)
 	if err != nil {
 		return nil, err
 	}
@@ -135,6 +162,7 @@ func (c *Client) processEvent(ctx context.Context, event *kubemq.Event) (*types.
 }
 
 func (c *Client) Stop() error {
+	c.pool.Close()
 	for _, client := range c.clients {
 		_ = client.Close()
 	}
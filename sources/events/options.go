@@ -26,6 +26,10 @@ type options struct {
 	maxReconnects            int
 	sources                  int
 	doNotParsePayload        bool
+	propagateMetadata        bool
+	maxConcurrency           int
+	ordered                  bool
+	orderedKey               string
 }
 
 func parseOptions(cfg config.Spec) (options, error) {
@@ -57,5 +61,15 @@ func parseOptions(cfg config.Spec) (options, error) {
 	o.reconnectIntervalSeconds = time.Duration(interval) * time.Second
 	o.maxReconnects = cfg.Properties.ParseInt("max_reconnects", 0)
 	o.doNotParsePayload = cfg.Properties.ParseBool("do_not_parse_payload", false)
+	o.propagateMetadata = cfg.Properties.ParseBool("propagate_metadata", true)
+	o.maxConcurrency, err = cfg.Properties.ParseIntWithRange("max_concurrency", 0, 0, 100000)
+	if err != nil {
+		return options{}, fmt.Errorf("error parsing max concurrency value, %w", err)
+	}
+	o.ordered = cfg.Properties.ParseBool("ordered", false)
+	if o.ordered && o.maxConcurrency == 0 {
+		return options{}, fmt.Errorf("ordered processing requires max concurrency to be set")
+	}
+	o.orderedKey = cfg.Properties.ParseString("ordered_key", "")
 	return o, nil
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/kubemq-io/kubemq-targets/config"
 	"github.com/kubemq-io/kubemq-targets/middleware"
 	"github.com/kubemq-io/kubemq-targets/pkg/logger"
+	"github.com/kubemq-io/kubemq-targets/pkg/tracing"
 	"github.com/kubemq-io/kubemq-targets/pkg/uuid"
 	"github.com/kubemq-io/kubemq-targets/types"
 
@@ -104,6 +105,9 @@ func (c *Client) runClient(ctx context.Context, client *kubemq.Client) error {
 					if err != nil {
 						resp = types.NewResponse().SetError(err)
 					}
+					if c.opts.propagateMetadata {
+						queryResponse.SetTags(resp.MergeTags(query.Tags).Tags())
+					}
 					queryResponse.SetExecutedAt(time.Now()).
 						SetBody(resp.MarshalBinary())
 					err = queryResponse.Send(ctx)
@@ -135,7 +139,18 @@ func (c *Client) processQuery(ctx context.Context, query *kubemq.QueryReceive) (
 			return nil, fmt.Errorf("invalid request format, %w", err)
 		}
 	}
+	if c.opts.propagateMetadata {
+		req.SetMessageContext(&types.MessageContext{
+			Id:       query.Id,
+			Channel:  query.Channel,
+			ClientId: query.ClientId,
+			Metadata: query.Metadata,
+			Tags:     query.Tags,
+		})
+	}
+	ctx, span := tracing.StartSource(ctx, "kubemq.query", query.Channel, query.Id, query.Tags)
 	resp, err := c.target.Do(ctx, req)
+	tracing.EndSpan(span, err)
 	if err != nil {
 		return nil, err
 	}
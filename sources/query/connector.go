@@ -38,6 +38,15 @@ func Connector() *common.Connector {
 				SetMust(false).
 				SetDefault("false"),
 		).
+		AddProperty(
+			common.NewProperty().
+				SetKind("bool").
+				SetName("propagate_metadata").
+				SetTitle("Propagate Message Metadata").
+				SetDescription("Set message id, channel, tags and attributes in request metadata").
+				SetMust(false).
+				SetDefault("true"),
+		).
 		AddProperty(
 			common.NewProperty().
 				SetKind("string").
@@ -25,6 +25,7 @@ type options struct {
 	maxReconnects            int
 	sources                  int
 	doNotParsePayload        bool
+	propagateMetadata        bool
 }
 
 func parseOptions(cfg config.Spec) (options, error) {
@@ -56,5 +57,6 @@ func parseOptions(cfg config.Spec) (options, error) {
 	o.reconnectIntervalSeconds = time.Duration(interval) * time.Second
 	o.maxReconnects = cfg.Properties.ParseInt("max_reconnects", 0)
 	o.doNotParsePayload = cfg.Properties.ParseBool("do_not_parse_payload", false)
+	o.propagateMetadata = cfg.Properties.ParseBool("propagate_metadata", true)
 	return o, nil
 }
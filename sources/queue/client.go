@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/kubemq-hub/builder/connector/common"
@@ -11,16 +12,18 @@ import (
 	"github.com/kubemq-io/kubemq-targets/config"
 	"github.com/kubemq-io/kubemq-targets/middleware"
 	"github.com/kubemq-io/kubemq-targets/pkg/logger"
+	"github.com/kubemq-io/kubemq-targets/pkg/tracing"
 	"github.com/kubemq-io/kubemq-targets/types"
 )
 
 var errInvalidTarget = errors.New("invalid controller received, cannot be null")
 
 type Client struct {
-	opts      options
-	log       *logger.Logger
-	target    middleware.Middleware
-	isStopped bool
+	opts       options
+	log        *logger.Logger
+	target     middleware.Middleware
+	cancelPoll context.CancelFunc
+	wg         sync.WaitGroup
 }
 
 func (c *Client) getQueuesClient(ctx context.Context, id int) (*queues_stream.QueuesStreamClient, error) {
@@ -69,31 +72,36 @@ func (c *Client) Start(ctx context.Context, target middleware.Middleware) error
 	} else {
 		c.target = target
 	}
+	var pollCtx context.Context
+	pollCtx, c.cancelPoll = context.WithCancel(ctx)
 	for i := 0; i < c.opts.sources; i++ {
 		client, err := c.getQueuesClient(ctx, i+1)
 		if err != nil {
 			return err
 		}
-		go c.run(ctx, client)
+		c.wg.Add(1)
+		go c.run(ctx, pollCtx, client)
 	}
 	return nil
 }
 
-func (c *Client) run(ctx context.Context, client *queues_stream.QueuesStreamClient) {
+// run polls messages until pollCtx is canceled, polled messages are processed with ctx so they complete on stop
+func (c *Client) run(ctx, pollCtx context.Context, client *queues_stream.QueuesStreamClient) {
+	defer c.wg.Done()
 	defer func() {
 		_ = client.Close()
 	}()
 	for {
-		if c.isStopped {
-			return
-		}
-		err := c.processQueueMessage(ctx, client)
-		if err != nil {
+		err := c.processQueueMessage(ctx, pollCtx, client)
+		if err != nil && pollCtx.Err() == nil {
 			c.log.Error(err.Error())
-			time.Sleep(time.Second)
+			select {
+			case <-time.After(time.Second):
+			case <-pollCtx.Done():
+			}
 		}
 		select {
-		case <-ctx.Done():
+		case <-pollCtx.Done():
 			return
 		default:
 
@@ -101,14 +109,14 @@ func (c *Client) run(ctx context.Context, client *queues_stream.QueuesStreamClie
 	}
 }
 
-func (c *Client) processQueueMessage(ctx context.Context, client *queues_stream.QueuesStreamClient) error {
+func (c *Client) processQueueMessage(ctx, pollCtx context.Context, client *queues_stream.QueuesStreamClient) error {
 	pr := queues_stream.NewPollRequest().
 		SetChannel(c.opts.channel).
 		SetMaxItems(c.opts.batchSize).
 		SetWaitTimeout(c.opts.waitTimeout * 1000).
-		SetAutoAck(true).
+		SetAutoAck(c.opts.autoAck).
 		SetOnErrorFunc(c.onError)
-	pollResp, err := client.Poll(ctx, pr)
+	pollResp, err := client.Poll(pollCtx, pr)
 	if err != nil {
 		return err
 	}
@@ -116,20 +124,18 @@ func (c *Client) processQueueMessage(ctx context.Context, client *queues_stream.
 		return nil
 	}
 	for _, message := range pollResp.Messages {
-		var req *types.Request
-		var err error
-		if c.opts.doNotParsePayload {
-			req = types.NewRequest().SetData(message.Body)
-		} else {
-			req, err = types.ParseRequest(message.Body)
-			if err != nil {
-				return fmt.Errorf("invalid request format, %w", err)
+		resp, err := c.processMessage(ctx, message)
+		if !c.opts.autoAck {
+			if errSettle := c.settleMessage(message, err); errSettle != nil {
+				c.log.Errorf("error settling queue message %s, %s", message.MessageID, errSettle.Error())
 			}
 		}
-		resp, err := c.target.Do(ctx, req)
 		if err != nil {
 			if c.opts.responseChannel != "" {
 				errResp := types.NewResponse().SetError(err)
+				if c.opts.propagateMetadata {
+					errResp.MergeTags(message.Tags)
+				}
 				_, errSend := client.Send(ctx, errResp.ToQueueStreamMessage().SetChannel(c.opts.responseChannel))
 				if errSend != nil {
 					c.log.Errorf("error sending response to a queue, %s", errSend.Error())
@@ -137,6 +143,9 @@ func (c *Client) processQueueMessage(ctx context.Context, client *queues_stream.
 			}
 		}
 		if resp != nil {
+			if c.opts.propagateMetadata {
+				resp.MergeTags(message.Tags)
+			}
 			if c.opts.responseChannel != "" {
 				_, errSend := client.Send(ctx, resp.ToQueueStreamMessage().SetChannel(c.opts.responseChannel))
 				if errSend != nil {
@@ -148,7 +157,70 @@ func (c *Client) processQueueMessage(ctx context.Context, client *queues_stream.
 	return nil
 }
 
+func (c *Client) processMessage(ctx context.Context, message *queues_stream.QueueMessage) (*types.Response, error) {
+	var req *types.Request
+	var err error
+	if c.opts.doNotParsePayload {
+		req = types.NewRequest().SetData(message.Body)
+	} else {
+		req, err = types.ParseRequest(message.Body)
+		if err != nil {
+			return nil, types.NewPermanentError(fmt.Errorf("invalid request format, %w", err))
+		}
+	}
+	if c.opts.propagateMetadata {
+		mc := &types.MessageContext{
+			Id:       message.MessageID,
+			Channel:  message.Channel,
+			ClientId: message.ClientID,
+			Metadata: message.Metadata,
+			Tags:     message.Tags,
+		}
+		if message.Attributes != nil {
+			mc.Timestamp = time.Unix(0, message.Attributes.Timestamp)
+			mc.Sequence = message.Attributes.Sequence
+			mc.ReceiveCount = int(message.Attributes.ReceiveCount)
+		}
+		req.SetMessageContext(mc)
+	}
+	ctx, span := tracing.StartSource(ctx, "kubemq.queue", message.Channel, message.MessageID, message.Tags)
+	resp, err := c.target.Do(ctx, req)
+	tracing.EndSpan(span, err)
+	return resp, err
+}
+
+// settleMessage acks a successfully processed message. Failed messages are
+// nacked for redelivery until max_receive_count is reached, then moved to the
+// dead letter queue, or dropped when none is configured. Messages which cannot
+// be parsed or failed with a permanent error are not redelivered.
+func (c *Client) settleMessage(message *queues_stream.QueueMessage, processErr error) error {
+	if processErr == nil {
+		return message.Ack()
+	}
+	reason := "failed permanently"
+	if !types.IsPermanentError(processErr) {
+		receiveCount := 0
+		if message.Attributes != nil {
+			receiveCount = int(message.Attributes.ReceiveCount)
+		}
+		if c.opts.maxReceiveCount == 0 || receiveCount < c.opts.maxReceiveCount {
+			return message.NAck()
+		}
+		reason = fmt.Sprintf("reached max receive count %d", c.opts.maxReceiveCount)
+	}
+	if c.opts.deadLetterQueue != "" {
+		c.log.Errorf("queue message %s %s, moving to dead letter queue %s", message.MessageID, reason, c.opts.deadLetterQueue)
+		return message.ReQueue(c.opts.deadLetterQueue)
+	}
+	c.log.Errorf("queue message %s %s, dropping message", message.MessageID, reason)
+	return message.Ack()
+}
+
+// Stop stops polling new messages and waits for the polled messages to be processed
 func (c *Client) Stop() error {
-	c.isStopped = true
+	if c.cancelPoll != nil {
+		c.cancelPoll()
+	}
+	c.wg.Wait()
 	return nil
 }
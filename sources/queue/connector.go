@@ -38,6 +38,15 @@ func Connector() *common.Connector {
 				SetMust(false).
 				SetDefault("false"),
 		).
+		AddProperty(
+			common.NewProperty().
+				SetKind("bool").
+				SetName("propagate_metadata").
+				SetTitle("Propagate Message Metadata").
+				SetDescription("Set message id, channel, tags and attributes in request metadata").
+				SetMust(false).
+				SetDefault("true"),
+		).
 		AddProperty(
 			common.NewProperty().
 				SetKind("string").
@@ -91,5 +100,32 @@ func Connector() *common.Connector {
 				SetDescription("Set how long to wait in seconds for messages during pull of requests").
 				SetMust(false).
 				SetDefault("5"),
+		).
+		AddProperty(
+			common.NewProperty().
+				SetKind("bool").
+				SetName("auto_ack").
+				SetTitle("Auto Ack").
+				SetDescription("Set whether messages are acked on receive or only after successful processing").
+				SetMust(false).
+				SetDefault("true"),
+		).
+		AddProperty(
+			common.NewProperty().
+				SetKind("int").
+				SetName("max_receive_count").
+				SetTitle("Max Receive Count").
+				SetDescription("Set how many times a failed message is redelivered, 0 for unlimited").
+				SetMust(false).
+				SetDefault("3"),
+		).
+		AddProperty(
+			common.NewProperty().
+				SetKind("string").
+				SetName("dead_letter_queue").
+				SetTitle("Dead Letter Queue").
+				SetDescription("Set queue channel for messages that reached max receive count").
+				SetMust(false).
+				SetDefault(""),
 		)
 }
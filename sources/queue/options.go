@@ -11,6 +11,8 @@ const (
 	defaultAddress     = "localhost:50000"
 	defaultWaitTimeout = 5
 	defaultSources     = 1
+	// defaultMaxReceiveCount bounds the redeliveries of failed messages when auto ack is disabled
+	defaultMaxReceiveCount = 3
 )
 
 type options struct {
@@ -24,6 +26,10 @@ type options struct {
 	batchSize         int
 	waitTimeout       int
 	doNotParsePayload bool
+	propagateMetadata bool
+	autoAck           bool
+	maxReceiveCount   int
+	deadLetterQueue   string
 }
 
 func parseOptions(cfg config.Spec) (options, error) {
@@ -57,5 +63,18 @@ func parseOptions(cfg config.Spec) (options, error) {
 		return options{}, fmt.Errorf("error parsing wait timeout value, %w", err)
 	}
 	o.doNotParsePayload = cfg.Properties.ParseBool("do_not_parse_payload", false)
+	o.propagateMetadata = cfg.Properties.ParseBool("propagate_metadata", true)
+	o.autoAck = cfg.Properties.ParseBool("auto_ack", true)
+	o.maxReceiveCount, err = cfg.Properties.ParseIntWithRange("max_receive_count", defaultMaxReceiveCount, 0, 1024)
+	if err != nil {
+		return options{}, fmt.Errorf("error parsing max receive count value, %w", err)
+	}
+	o.deadLetterQueue = cfg.Properties.ParseString("dead_letter_queue", "")
+	if o.deadLetterQueue != "" && o.autoAck {
+		return options{}, fmt.Errorf("dead letter queue requires auto ack to be disabled")
+	}
+	if o.deadLetterQueue == o.channel {
+		return options{}, fmt.Errorf("dead letter queue cannot be the source channel")
+	}
 	return o, nil
 }
@@ -30,15 +30,18 @@ func TestOptions_parseOptions(t *testing.T) {
 				},
 			},
 			want: options{
-				host:            "localhost",
-				port:            50000,
-				clientId:        "some-clients-id",
-				authToken:       "some-auth token",
-				channel:         "some-channel",
-				responseChannel: "some-response-channel",
-				sources:         1,
-				waitTimeout:     60,
-				batchSize:       2,
+				host:              "localhost",
+				port:              50000,
+				clientId:          "some-clients-id",
+				authToken:         "some-auth token",
+				channel:           "some-channel",
+				responseChannel:   "some-response-channel",
+				sources:           1,
+				waitTimeout:       60,
+				batchSize:         2,
+				propagateMetadata: true,
+				autoAck:           true,
+				maxReceiveCount:   3,
 			},
 			wantErr: false,
 		},
@@ -99,6 +102,64 @@ func TestOptions_parseOptions(t *testing.T) {
 			want:    options{},
 			wantErr: true,
 		},
+		{
+			name: "valid options - manual ack with dead letter queue",
+			cfg: config.Spec{
+				Name: "kubemq-rpc",
+				Kind: "",
+				Properties: map[string]string{
+					"address":           "localhost:50000",
+					"client_id":         "some-clients-id",
+					"channel":           "some-channel",
+					"auto_ack":          "false",
+					"max_receive_count": "3",
+					"dead_letter_queue": "some-dead-letter",
+				},
+			},
+			want: options{
+				host:              "localhost",
+				port:              50000,
+				clientId:          "some-clients-id",
+				channel:           "some-channel",
+				sources:           1,
+				waitTimeout:       defaultWaitTimeout,
+				batchSize:         1,
+				propagateMetadata: true,
+				autoAck:           false,
+				maxReceiveCount:   3,
+				deadLetterQueue:   "some-dead-letter",
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid options - dead letter queue with auto ack",
+			cfg: config.Spec{
+				Name: "kubemq-rpc",
+				Kind: "",
+				Properties: map[string]string{
+					"address":           "localhost:50000",
+					"channel":           "some-channel",
+					"dead_letter_queue": "some-dead-letter",
+				},
+			},
+			want:    options{},
+			wantErr: true,
+		},
+		{
+			name: "invalid options - bad max receive count",
+			cfg: config.Spec{
+				Name: "kubemq-rpc",
+				Kind: "",
+				Properties: map[string]string{
+					"address":           "localhost:50000",
+					"channel":           "some-channel",
+					"auto_ack":          "false",
+					"max_receive_count": "-1",
+				},
+			},
+			want:    options{},
+			wantErr: true,
+		},
 		{
 			name: "invalid options - bad sources",
 			cfg: config.Spec{
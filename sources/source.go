@@ -22,6 +22,11 @@ type Source interface {
 	Connector() *common.Connector
 }
 
+// QueueDepthReporter is implemented by sources that queue received messages for a bounded worker pool
+type QueueDepthReporter interface {
+	SetQueueDepthReporter(report func(depth int))
+}
+
 func Init(ctx context.Context, cfg config.Spec, log *logger.Logger) (Source, error) {
 	switch cfg.Kind {
 	case "source.command", "kubemq.command":
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
@@ -58,7 +59,7 @@ func (c *Client) Init(ctx context.Context, cfg config.Spec, log *logger.Logger)
 func (c *Client) Do(ctx context.Context, req *types.Request) (*types.Response, error) {
 	meta, err := parseMetadata(req.Metadata)
 	if err != nil {
-		return nil, err
+		return nil, types.NewPermanentError(err)
 	}
 	switch meta.method {
 	case "list_streams":
@@ -262,6 +263,65 @@ func (c *Client) createStreamConsumer(ctx context.Context, meta metadata) (*type
 		nil
 }
 
+func (c *Client) DoBatch(ctx context.Context, requests []*types.Request) ([]*types.Response, []error) {
+	responses := make([]*types.Response, len(requests))
+	errs := make([]error, len(requests))
+	streams := map[string][]int{}
+	var streamNames []string
+	for i, req := range requests {
+		meta, err := parseMetadata(req.Metadata)
+		if err != nil {
+			errs[i] = types.NewPermanentError(err)
+			continue
+		}
+		if meta.method != "put_record" {
+			responses[i], errs[i] = c.Do(ctx, req)
+			continue
+		}
+		if _, ok := streams[meta.streamName]; !ok {
+			streamNames = append(streamNames, meta.streamName)
+		}
+		streams[meta.streamName] = append(streams[meta.streamName], i)
+	}
+	for _, streamName := range streamNames {
+		indexes := streams[streamName]
+		var records []*kinesis.PutRecordsRequestEntry
+		for _, i := range indexes {
+			records = append(records, &kinesis.PutRecordsRequestEntry{
+				Data:         requests[i].Data,
+				PartitionKey: aws.String(requests[i].Metadata["partition_key"]),
+			})
+		}
+		m, err := c.client.PutRecordsWithContext(ctx, &kinesis.PutRecordsInput{
+			Records:    records,
+			StreamName: aws.String(streamName),
+		})
+		if err == nil && len(m.Records) != len(indexes) {
+			err = fmt.Errorf("put records result count %d does not match records count %d", len(m.Records), len(indexes))
+		}
+		for n, i := range indexes {
+			if err != nil {
+				errs[i] = err
+				continue
+			}
+			record := m.Records[n]
+			if record.ErrorCode != nil {
+				errs[i] = fmt.Errorf("%s: %s", aws.StringValue(record.ErrorCode), aws.StringValue(record.ErrorMessage))
+				continue
+			}
+			b, err := json.Marshal(record)
+			if err != nil {
+				errs[i] = err
+				continue
+			}
+			responses[i] = types.NewResponse().
+				SetMetadataKeyValue("result", "ok").
+				SetData(b)
+		}
+	}
+	return responses, errs
+}
+
 func (c *Client) Stop() error {
 	return nil
 }
@@ -3,11 +3,16 @@ package sns
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/sns"
 )
 
+// maxMessageAttributes is the sns limit of message attributes
+const maxMessageAttributes = 10
+
 type Attributes struct {
 	Name        string `json:"name"`
 	DataType    string `json:"data_type"`
@@ -49,9 +54,50 @@ func (c *Client) createSNSMessage(meta metadata, data []byte) (*sns.PublishInput
 			StringValue: aws.String(i.StringValue),
 		}
 	}
+	// the source message attributes and tags are added up to the sns limit, skipping the names set in the request data
+	for _, header := range meta.headers {
+		if len(a) == maxMessageAttributes {
+			break
+		}
+		if _, ok := a[header.Key]; ok || !validAttributeName(header.Key) || !validAttributeValue(header.Value) {
+			continue
+		}
+		a[header.Key] = &sns.MessageAttributeValue{
+			DataType:    aws.String("String"),
+			StringValue: aws.String(header.Value),
+		}
+	}
 	if len(a) > 0 {
 		i.MessageAttributes = a
 	}
 
 	return i, nil
 }
+
+func validAttributeName(name string) bool {
+	if name == "" || len(name) > 256 || strings.HasPrefix(name, ".") || strings.HasSuffix(name, ".") || strings.Contains(name, "..") {
+		return false
+	}
+	lower := strings.ToLower(name)
+	if strings.HasPrefix(lower, "aws.") || strings.HasPrefix(lower, "amazon.") {
+		return false
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-' || r == '.') {
+			return false
+		}
+	}
+	return true
+}
+
+func validAttributeValue(value string) bool {
+	if value == "" || !utf8.ValidString(value) {
+		return false
+	}
+	for _, r := range value {
+		if r < 0x20 && r != '\t' && r != '\n' && r != '\r' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,68 @@
+package sns
+
+import (
+	"testing"
+
+	"github.com/kubemq-io/kubemq-targets/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_createSNSMessage(t *testing.T) {
+	tests := []struct {
+		name       string
+		meta       types.Metadata
+		data       []byte
+		wantValues map[string]string
+	}{
+		{
+			name: "no message attributes",
+			meta: map[string]string{
+				"method":  "send_message",
+				"topic":   "some-topic",
+				"message": "some-message",
+			},
+		},
+		{
+			name: "message attributes and tags",
+			meta: map[string]string{
+				"method":                    "send_message",
+				"topic":                     "some-topic",
+				"message":                   "some-message",
+				"kubemq.id":                 "id-1",
+				"kubemq.tag.correlation_id": "abc",
+				"kubemq.tag.bad name":       "value",
+			},
+			wantValues: map[string]string{
+				"kubemq.id":                 "id-1",
+				"kubemq.tag.correlation_id": "abc",
+			},
+		},
+		{
+			name: "request data attributes are kept",
+			meta: map[string]string{
+				"method":                    "send_message",
+				"topic":                     "some-topic",
+				"message":                   "some-message",
+				"kubemq.tag.correlation_id": "abc",
+			},
+			data: []byte(`[{"name":"kubemq.tag.correlation_id","data_type":"String","string_value":"set-by-request"}]`),
+			wantValues: map[string]string{
+				"kubemq.tag.correlation_id": "set-by-request",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meta, err := parseMetadata(tt.meta)
+			require.NoError(t, err)
+			c := &Client{}
+			input, err := c.createSNSMessage(meta, tt.data)
+			require.NoError(t, err)
+			require.Len(t, input.MessageAttributes, len(tt.wantValues))
+			for name, value := range tt.wantValues {
+				require.Contains(t, input.MessageAttributes, name)
+				require.Equal(t, value, *input.MessageAttributes[name].StringValue)
+			}
+		})
+	}
+}
@@ -24,6 +24,7 @@ type metadata struct {
 	phoneNumber string
 	subject     string
 	targetArn   string
+	headers     []types.MessageHeader
 }
 
 var methodsMap = map[string]string{
@@ -63,6 +64,7 @@ func parseMetadata(meta types.Metadata) (metadata, error) {
 			}
 		}
 	} else if m.method == "send_message" {
+		m.headers = meta.MessageHeaders()
 		m.targetArn, err = meta.MustParseString("target_arn")
 		if err != nil {
 			m.topic, err = meta.MustParseString("topic")
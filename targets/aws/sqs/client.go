@@ -65,6 +65,7 @@ func (c *Client) Do(ctx context.Context, request *types.Request) (*types.Respons
 			return nil, err
 		}
 	}
+	eventMetadata.tags = messageAttributes(eventMetadata.tags, request.Metadata)
 	m := &sqs.SendMessageInput{}
 	c.setMessageMeta(m, eventMetadata)
 	m.SetMessageBody(string(request.Data))
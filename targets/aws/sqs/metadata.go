@@ -2,6 +2,8 @@ package sqs
 
 import (
 	"fmt"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/sqs"
@@ -36,3 +38,56 @@ func parseMetadata(meta types.Metadata, opts options) (metadata, error) {
 	}
 	return m, nil
 }
+
+// maxMessageAttributes is the sqs limit of message attributes
+const maxMessageAttributes = 10
+
+// messageAttributes returns tags with the source message attributes and tags of the request, up to the sqs limit of
+// message attributes. The names set in tags are kept and the names and values not allowed by sqs are skipped
+func messageAttributes(tags map[string]*sqs.MessageAttributeValue, meta types.Metadata) map[string]*sqs.MessageAttributeValue {
+	attributes := map[string]*sqs.MessageAttributeValue{}
+	for name, value := range tags {
+		attributes[name] = value
+	}
+	for _, header := range meta.MessageHeaders() {
+		if len(attributes) == maxMessageAttributes {
+			break
+		}
+		if _, ok := attributes[header.Key]; ok || !validAttributeName(header.Key) || !validAttributeValue(header.Value) {
+			continue
+		}
+		attributes[header.Key] = &sqs.MessageAttributeValue{
+			DataType:    aws.String("String"),
+			StringValue: aws.String(header.Value),
+		}
+	}
+	return attributes
+}
+
+func validAttributeName(name string) bool {
+	if name == "" || len(name) > 256 || strings.HasPrefix(name, ".") || strings.HasSuffix(name, ".") || strings.Contains(name, "..") {
+		return false
+	}
+	lower := strings.ToLower(name)
+	if strings.HasPrefix(lower, "aws.") || strings.HasPrefix(lower, "amazon.") {
+		return false
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-' || r == '.') {
+			return false
+		}
+	}
+	return true
+}
+
+func validAttributeValue(value string) bool {
+	if value == "" || !utf8.ValidString(value) {
+		return false
+	}
+	for _, r := range value {
+		if r < 0x20 && r != '\t' && r != '\n' && r != '\r' {
+			return false
+		}
+	}
+	return true
+}
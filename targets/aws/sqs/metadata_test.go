@@ -0,0 +1,96 @@
+package sqs
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/kubemq-io/kubemq-targets/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetadata_messageAttributes(t *testing.T) {
+	stringValue := func(value string) *sqs.MessageAttributeValue {
+		return &sqs.MessageAttributeValue{DataType: aws.String("String"), StringValue: aws.String(value)}
+	}
+	manyTags := map[string]*sqs.MessageAttributeValue{}
+	for i := 0; i < maxMessageAttributes-1; i++ {
+		manyTags[fmt.Sprintf("tag-%d", i)] = stringValue("value")
+	}
+	tests := []struct {
+		name       string
+		tags       map[string]*sqs.MessageAttributeValue
+		meta       types.Metadata
+		wantNames  []string
+		wantValues map[string]string
+	}{
+		{
+			name:       "no message attributes",
+			tags:       map[string]*sqs.MessageAttributeValue{"tag": stringValue("value")},
+			meta:       map[string]string{"queue": "some-queue"},
+			wantValues: map[string]string{"tag": "value"},
+		},
+		{
+			name: "message attributes and tags",
+			meta: map[string]string{
+				"kubemq.id":                 "id-1",
+				"kubemq.tag.correlation_id": "abc",
+			},
+			wantValues: map[string]string{
+				"kubemq.id":                 "id-1",
+				"kubemq.tag.correlation_id": "abc",
+			},
+		},
+		{
+			name: "request tags are kept",
+			tags: map[string]*sqs.MessageAttributeValue{"kubemq.tag.correlation_id": stringValue("set-by-request")},
+			meta: map[string]string{
+				"kubemq.tag.correlation_id": "abc",
+			},
+			wantValues: map[string]string{
+				"kubemq.tag.correlation_id": "set-by-request",
+			},
+		},
+		{
+			name: "invalid names and values are skipped",
+			meta: map[string]string{
+				"kubemq.tag.bad name":  "value",
+				"kubemq.tag..dots":     "value",
+				"kubemq.tag.empty":     "",
+				"kubemq.tag.valid":     "value",
+				"kubemq.tag.bad-value": "\x00",
+			},
+			wantValues: map[string]string{
+				"kubemq.tag.valid": "value",
+			},
+		},
+		{
+			name: "limited to the sqs max attributes with tags first",
+			tags: manyTags,
+			meta: map[string]string{
+				"kubemq.id":                 "id-1",
+				"kubemq.tag.correlation_id": "abc",
+			},
+			wantNames: []string{"kubemq.tag.correlation_id"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attributes := messageAttributes(tt.tags, tt.meta)
+			if tt.wantValues != nil {
+				require.Len(t, attributes, len(tt.wantValues))
+				for name, value := range tt.wantValues {
+					require.Contains(t, attributes, name)
+					require.Equal(t, value, *attributes[name].StringValue)
+				}
+			}
+			if tt.wantNames != nil {
+				require.Len(t, attributes, maxMessageAttributes)
+				for _, name := range tt.wantNames {
+					require.Contains(t, attributes, name)
+				}
+			}
+		})
+	}
+}
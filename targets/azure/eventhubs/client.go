@@ -48,7 +48,7 @@ func (c *Client) Init(ctx context.Context, cfg config.Spec, log *logger.Logger)
 func (c *Client) Do(ctx context.Context, req *types.Request) (*types.Response, error) {
 	meta, err := parseMetadata(req.Metadata)
 	if err != nil {
-		return nil, err
+		return nil, types.NewPermanentError(err)
 	}
 	switch meta.method {
 	case "send":
@@ -107,6 +107,48 @@ func (c *Client) sendBatch(ctx context.Context, meta metadata, data []byte) (*ty
 		nil
 }
 
+func (c *Client) DoBatch(ctx context.Context, requests []*types.Request) ([]*types.Response, []error) {
+	responses := make([]*types.Response, len(requests))
+	errs := make([]error, len(requests))
+	var events []*eventhub.Event
+	var indexes []int
+	for i, req := range requests {
+		meta, err := parseMetadata(req.Metadata)
+		if err != nil {
+			errs[i] = types.NewPermanentError(err)
+			continue
+		}
+		if meta.method != "send" {
+			responses[i], errs[i] = c.Do(ctx, req)
+			continue
+		}
+		event := &eventhub.Event{
+			Data: req.Data,
+		}
+		if meta.partitionKey != "" {
+			event.PartitionKey = &meta.partitionKey
+		}
+		if meta.properties != nil {
+			event.Properties = meta.properties
+		}
+		events = append(events, event)
+		indexes = append(indexes, i)
+	}
+	if len(events) == 0 {
+		return responses, errs
+	}
+	err := c.client.SendBatch(ctx, eventhub.NewEventBatchIterator(events...))
+	for _, i := range indexes {
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		responses[i] = types.NewResponse().
+			SetMetadataKeyValue("result", "ok")
+	}
+	return responses, errs
+}
+
 func (c *Client) Stop() error {
 	if c.client != nil {
 		return c.client.Close(context.Background())
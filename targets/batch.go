@@ -0,0 +1,13 @@
+package targets
+
+import (
+	"context"
+
+	"github.com/kubemq-io/kubemq-targets/types"
+)
+
+// BatchTarget is an optional interface for targets that can process several requests in one bulk call.
+// DoBatch returns one response and one error per request, in the same order as the requests.
+type BatchTarget interface {
+	DoBatch(ctx context.Context, requests []*types.Request) ([]*types.Response, []error)
+}
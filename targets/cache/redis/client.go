@@ -191,6 +191,11 @@ func (c *Client) Delete(ctx context.Context, meta metadata) (*types.Response, er
 		nil
 }
 
+// Check pings the redis server
+func (c *Client) Check(ctx context.Context) error {
+	return c.redis.WithContext(ctx).Ping().Err()
+}
+
 func (c *Client) Stop() error {
 	if c.redis != nil {
 		return c.redis.Close()
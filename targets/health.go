@@ -0,0 +1,9 @@
+package targets
+
+import "context"
+
+// HealthChecker is an optional interface for targets that can probe their downstream, as a database ping or a broker connection state.
+// Check returns an error when the downstream is not reachable.
+type HealthChecker interface {
+	Check(ctx context.Context) error
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/kubemq-hub/builder/connector/common"
 	"github.com/kubemq-io/kubemq-targets/config"
 	"github.com/kubemq-io/kubemq-targets/pkg/logger"
+	"github.com/kubemq-io/kubemq-targets/pkg/tracing"
 	"github.com/kubemq-io/kubemq-targets/types"
 )
 
@@ -68,10 +69,12 @@ func (c *Client) Init(ctx context.Context, cfg config.Spec, log *logger.Logger)
 func (c *Client) Do(ctx context.Context, req *types.Request) (*types.Response, error) {
 	meta, err := parseMetadata(req.Metadata)
 	if err != nil {
-		return nil, err
+		return nil, types.NewPermanentError(err)
 	}
 	httpReq := c.client.R().
+		SetHeaders(messageHeaders(req)).
 		SetHeaders(meta.headers).
+		SetHeaders(tracing.Inject(ctx)).
 		SetContext(ctx)
 
 	if req.Data != nil {
@@ -83,6 +86,10 @@ func (c *Client) Do(ctx context.Context, req *types.Request) (*types.Response, e
 	if err != nil {
 		return nil, err
 	}
+	if resp.RawResponse.StatusCode == http.StatusTooManyRequests {
+		_ = resp.RawResponse.Body.Close()
+		return nil, types.NewThrottledError(fmt.Errorf("http request throttled, %s", resp.RawResponse.Status), types.ParseRetryAfter(resp.RawResponse.Header.Get("Retry-After")))
+	}
 	tr, err := newResultFromHttpResponse(resp.RawResponse)
 	if err != nil {
 		return nil, err
@@ -111,6 +118,21 @@ func newResultFromHttpResponse(hr *http.Response) (*types.Response, error) {
 	return resp, nil
 }
 
+// Check sends a HEAD request to the health check url, any response below 500 is healthy
+func (c *Client) Check(ctx context.Context) error {
+	if c.opts.healthCheckUrl == "" {
+		return nil
+	}
+	resp, err := c.client.R().SetContext(ctx).Head(c.opts.healthCheckUrl)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() >= http.StatusInternalServerError {
+		return fmt.Errorf("health check url returned %s", resp.Status())
+	}
+	return nil
+}
+
 func (c *Client) Stop() error {
 	return nil
 }
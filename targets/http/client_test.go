@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	nethttp "net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -45,6 +47,7 @@ type mockHttpServer struct {
 	port      string
 	postError bool
 	getError  bool
+	throttle  bool
 }
 
 func (m *mockHttpServer) start() {
@@ -53,6 +56,10 @@ func (m *mockHttpServer) start() {
 		if m.postError {
 			return c.String(500, "")
 		}
+		if m.throttle {
+			c.Response().Header().Set("Retry-After", "2")
+			return c.String(429, "")
+		}
 		p := &payload{}
 		err := c.Bind(p)
 		if err != nil {
@@ -78,12 +85,13 @@ func (m *mockHttpServer) stop(ctx context.Context) {
 
 func TestClient_Do(t *testing.T) {
 	tests := []struct {
-		name    string
-		mock    *mockHttpServer
-		cfg     config.Spec
-		request *types.Request
-		want    *types.Response
-		wantErr bool
+		name         string
+		mock         *mockHttpServer
+		cfg          config.Spec
+		request      *types.Request
+		want         *types.Response
+		wantErr      bool
+		wantErrClass types.ErrorClass
 	}{
 		{
 			name: "valid request - post",
@@ -160,8 +168,33 @@ func TestClient_Do(t *testing.T) {
 				SetMetadataKeyValue("method", "post").
 				SetMetadataKeyValue("url", "http://localhost:40001/post").
 				SetData(newPayload("some-data").Marshal()),
-			want:    nil,
-			wantErr: true,
+			want:         nil,
+			wantErr:      true,
+			wantErrClass: types.ErrorClassTransient,
+		},
+		{
+			name: "valid request - throttled",
+			mock: &mockHttpServer{
+				port:      "30006",
+				postError: false,
+				getError:  false,
+				throttle:  true,
+			},
+			cfg: config.Spec{
+				Name: "http",
+				Kind: "http",
+				Properties: map[string]string{
+					"auth_type":       "no_auth",
+					"default_headers": `{"Content-Type":"application/json"}`,
+				},
+			},
+			request: types.NewRequest().
+				SetMetadataKeyValue("method", "post").
+				SetMetadataKeyValue("url", "http://localhost:30006/post").
+				SetData(newPayload("some-data").Marshal()),
+			want:         nil,
+			wantErr:      true,
+			wantErrClass: types.ErrorClassThrottled,
 		},
 		{
 			name: "invalid request - method not supported",
@@ -185,8 +218,9 @@ func TestClient_Do(t *testing.T) {
 				SetMetadataKeyValue("method", "invalid-method").
 				SetMetadataKeyValue("url", "http://localhost:30003/post").
 				SetData(newPayload("some-data").Marshal()),
-			want:    nil,
-			wantErr: true,
+			want:         nil,
+			wantErr:      true,
+			wantErrClass: types.ErrorClassPermanent,
 		},
 		{
 			name: "invalid request - no method",
@@ -209,8 +243,9 @@ func TestClient_Do(t *testing.T) {
 			request: types.NewRequest().
 				SetMetadataKeyValue("url", "http://localhost:30004/post").
 				SetData(newPayload("some-data").Marshal()),
-			want:    nil,
-			wantErr: true,
+			want:         nil,
+			wantErr:      true,
+			wantErrClass: types.ErrorClassPermanent,
 		},
 		{
 			name: "invalid request - no url",
@@ -236,8 +271,9 @@ func TestClient_Do(t *testing.T) {
 				SetMetadataKeyValue("headers", `invalid-format`).
 				SetData(newPayload("some-data").Marshal()),
 
-			want:    nil,
-			wantErr: true,
+			want:         nil,
+			wantErr:      true,
+			wantErrClass: types.ErrorClassPermanent,
 		},
 		{
 			name: "invalid request - bad headers format",
@@ -260,8 +296,9 @@ func TestClient_Do(t *testing.T) {
 			request: types.NewRequest().
 				SetMetadataKeyValue("method", "post").
 				SetData(newPayload("some-data").Marshal()),
-			want:    nil,
-			wantErr: true,
+			want:         nil,
+			wantErr:      true,
+			wantErrClass: types.ErrorClassPermanent,
 		},
 	}
 	for _, tt := range tests {
@@ -277,6 +314,7 @@ func TestClient_Do(t *testing.T) {
 			got, err := c.Do(ctx, tt.request)
 			if tt.wantErr {
 				require.Error(t, err)
+				require.Equal(t, tt.wantErrClass, types.ErrorClassOf(err))
 				return
 			}
 			require.NoError(t, err)
@@ -370,3 +408,68 @@ func TestClient_Init(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_Do_MessageHeaders(t *testing.T) {
+	tests := []struct {
+		name        string
+		request     *types.Request
+		wantHeaders map[string]string
+		wantMissing []string
+	}{
+		{
+			name: "message attributes and tags",
+			request: types.NewRequest().
+				SetMetadataKeyValue("method", "get").
+				SetMetadataKeyValue("kubemq.id", "id-1").
+				SetMetadataKeyValue("kubemq.tag.correlation_id", "abc"),
+			wantHeaders: map[string]string{
+				"kubemq.id":                 "id-1",
+				"kubemq.tag.correlation_id": "abc",
+			},
+		},
+		{
+			name: "request headers are kept",
+			request: types.NewRequest().
+				SetMetadataKeyValue("method", "get").
+				SetMetadataKeyValue("headers", `{"kubemq.tag.correlation_id":"set-by-request"}`).
+				SetMetadataKeyValue("kubemq.tag.correlation_id", "abc"),
+			wantHeaders: map[string]string{
+				"kubemq.tag.correlation_id": "set-by-request",
+			},
+		},
+		{
+			name: "invalid headers are skipped",
+			request: types.NewRequest().
+				SetMetadataKeyValue("method", "get").
+				SetMetadataKeyValue("kubemq.tag.bad name", "value").
+				SetMetadataKeyValue("kubemq.tag.bad_value", "line\nbreak"),
+			wantMissing: []string{"kubemq.tag.bad name", "kubemq.tag.bad_value"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers := make(chan nethttp.Header, 1)
+			server := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+				headers <- r.Header
+			}))
+			defer server.Close()
+			target := New()
+			err := target.Init(context.Background(), config.Spec{
+				Name:       "http",
+				Kind:       "http",
+				Properties: map[string]string{"auth_type": "no_auth"},
+			}, nil)
+			require.NoError(t, err)
+			tt.request.SetMetadataKeyValue("url", server.URL)
+			_, err = target.Do(context.Background(), tt.request)
+			require.NoError(t, err)
+			received := <-headers
+			for name, value := range tt.wantHeaders {
+				require.Equal(t, value, received.Get(name))
+			}
+			for _, name := range tt.wantMissing {
+				require.Empty(t, received.Values(name))
+			}
+		})
+	}
+}
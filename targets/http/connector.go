@@ -75,6 +75,14 @@ func Connector() *common.Connector {
 				SetMust(false).
 				SetDefault(""),
 		).
+		AddProperty(
+			common.NewProperty().
+				SetKind("string").
+				SetName("health_check_url").
+				SetDescription("Set url probed with HEAD requests for health checks").
+				SetMust(false).
+				SetDefault(""),
+		).
 		AddProperty(
 			common.NewProperty().
 				SetKind("string").
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/kubemq-io/kubemq-targets/types"
+	"golang.org/x/net/http/httpguts"
 )
 
 var methodsMap = map[string]string{
@@ -48,3 +49,15 @@ func parseMetadata(meta types.Metadata) (metadata, error) {
 	}
 	return m, nil
 }
+
+// messageHeaders returns the source message attributes and tags of the request as headers, the ones which are not valid
+// http header fields are skipped
+func messageHeaders(req *types.Request) map[string]string {
+	headers := map[string]string{}
+	for _, header := range req.Metadata.MessageHeaders() {
+		if httpguts.ValidHeaderFieldName(header.Key) && httpguts.ValidHeaderFieldValue(header.Value) {
+			headers[header.Key] = header.Value
+		}
+	}
+	return headers
+}
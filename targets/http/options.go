@@ -16,6 +16,7 @@ type options struct {
 	clientPrivateKey string
 	clientPublicKey  string
 	defaultHeaders   map[string]string
+	healthCheckUrl   string
 }
 
 func parseOptions(cfg config.Spec) (options, error) {
@@ -39,6 +40,7 @@ func parseOptions(cfg config.Spec) (options, error) {
 	o.rootCertificate = cfg.Properties.ParseString("root_certificate", "")
 	o.clientPrivateKey = cfg.Properties.ParseString("client_private_key", "")
 	o.clientPublicKey = cfg.Properties.ParseString("client_public_key", "")
+	o.healthCheckUrl = cfg.Properties.ParseString("health_check_url", "")
 	o.defaultHeaders, err = cfg.Properties.MustParseJsonMap("default_headers")
 	if err != nil {
 		return options{}, fmt.Errorf("error parsing default_headers value, %w", err)
@@ -9,6 +9,7 @@ import (
 
 	"github.com/kubemq-hub/builder/connector/common"
 	"github.com/kubemq-io/kubemq-targets/pkg/logger"
+	"github.com/kubemq-io/kubemq-targets/pkg/tracing"
 
 	kafka "github.com/Shopify/sarama"
 	"github.com/kubemq-io/kubemq-targets/config"
@@ -86,8 +87,12 @@ func (c *Client) Do(ctx context.Context, request *types.Request) (*types.Respons
 		return nil, err
 	}
 
+	headers := m.Headers
+	for key, value := range tracing.Inject(ctx) {
+		headers = append(headers, kafka.RecordHeader{Key: []byte(key), Value: []byte(value)})
+	}
 	partition, offset, err := c.producer.SendMessage(&kafka.ProducerMessage{
-		Headers: m.Headers,
+		Headers: headers,
 		Key:     kafka.ByteEncoder(m.Key),
 		Value:   kafka.ByteEncoder(request.Data),
 		Topic:   c.opts.topic,
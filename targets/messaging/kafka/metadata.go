@@ -22,6 +22,7 @@ func parseMetadata(meta types.Metadata, opts options) (metadata, error) {
 	if err != nil {
 		return metadata{}, fmt.Errorf("error parsing headers, %w", err)
 	}
+	m.Headers = append(m.Headers, messageHeaders(meta)...)
 	k := meta.ParseString("key", "")
 	err = m.parseKey(k)
 	if err != nil {
@@ -55,3 +56,12 @@ func (meta *metadata) parseKey(key string) error {
 	}
 	return nil
 }
+
+// messageHeaders returns the source message attributes and tags of the request as record headers
+func messageHeaders(meta types.Metadata) []kafka.RecordHeader {
+	var headers []kafka.RecordHeader
+	for _, header := range meta.MessageHeaders() {
+		headers = append(headers, kafka.RecordHeader{Key: []byte(header.Key), Value: []byte(header.Value)})
+	}
+	return headers
+}
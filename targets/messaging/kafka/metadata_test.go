@@ -90,3 +90,36 @@ func TestMetadata_parseMeta(t *testing.T) {
 		})
 	}
 }
+
+func TestMetadata_messageHeaders(t *testing.T) {
+	tests := []struct {
+		name        string
+		meta        types.Metadata
+		wantHeaders []kafka.RecordHeader
+	}{
+		{
+			name: "no message attributes",
+			meta: map[string]string{},
+		},
+		{
+			name: "message attributes after headers",
+			meta: map[string]string{
+				"headers":                   `[{"Key":"aGVhZGVy","Value":"dmFsdWU="}]`,
+				"kubemq.id":                 "id-1",
+				"kubemq.tag.correlation_id": "abc",
+			},
+			wantHeaders: []kafka.RecordHeader{
+				{Key: []byte("header"), Value: []byte("value")},
+				{Key: []byte("kubemq.tag.correlation_id"), Value: []byte("abc")},
+				{Key: []byte("kubemq.id"), Value: []byte("id-1")},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meta, err := parseMetadata(tt.meta, options{})
+			require.NoError(t, err)
+			require.Equal(t, tt.wantHeaders, meta.Headers)
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/kubemq-hub/builder/connector/common"
 	"github.com/kubemq-io/kubemq-targets/config"
 	"github.com/kubemq-io/kubemq-targets/pkg/logger"
+	"github.com/kubemq-io/kubemq-targets/pkg/tracing"
 	"github.com/kubemq-io/kubemq-targets/types"
 	"github.com/nats-io/nats.go"
 )
@@ -53,13 +54,31 @@ func (c *Client) Do(ctx context.Context, req *types.Request) (*types.Response, e
 	if err != nil {
 		return nil, err
 	}
-	err = c.client.Publish(meta.subject, req.Data)
+	headers := tracing.Inject(ctx)
+	if len(headers) > 0 && c.client.HeadersSupported() {
+		msg := nats.NewMsg(meta.subject)
+		msg.Data = req.Data
+		for key, value := range headers {
+			msg.Header.Set(key, value)
+		}
+		err = c.client.PublishMsg(msg)
+	} else {
+		err = c.client.Publish(meta.subject, req.Data)
+	}
 	if err != nil {
 		return nil, err
 	}
 	return types.NewResponse().SetMetadataKeyValue("result", "ok"), nil
 }
 
+// Check returns an error when the nats connection is not connected
+func (c *Client) Check(ctx context.Context) error {
+	if !c.client.IsConnected() {
+		return fmt.Errorf("nats connection status is %s", c.client.Status().String())
+	}
+	return nil
+}
+
 func (c *Client) Stop() error {
 	if c.client != nil {
 		c.client.Close()
@@ -11,6 +11,7 @@ import (
 	"github.com/kubemq-hub/builder/connector/common"
 	"github.com/kubemq-io/kubemq-targets/config"
 	"github.com/kubemq-io/kubemq-targets/pkg/logger"
+	"github.com/kubemq-io/kubemq-targets/pkg/tracing"
 	"github.com/kubemq-io/kubemq-targets/types"
 	"github.com/streadway/amqp"
 )
@@ -108,6 +109,7 @@ func (c *Client) Do(ctx context.Context, req *types.Request) (*types.Response, e
 			return nil, err
 		}
 	}
+	meta.headers = req.Metadata.MessageHeaders()
 	return c.Publish(ctx, meta, req.Data)
 }
 
@@ -120,6 +122,9 @@ func (c *Client) Publish(ctx context.Context, meta metadata, data []byte) (*type
 		}
 	}
 	msg := meta.amqpMessage(data)
+	for key, value := range tracing.Inject(ctx) {
+		msg.Headers[key] = value
+	}
 	err := c.channel.Publish(meta.exchange, meta.queue, meta.mandatory, meta.immediate, msg)
 	if err != nil {
 		c.isConnected = false
@@ -130,6 +135,16 @@ func (c *Client) Publish(ctx context.Context, meta metadata, data []byte) (*type
 		nil
 }
 
+// Check returns an error when the connection to rabbitmq is closed
+func (c *Client) Check(ctx context.Context) error {
+	c.Lock()
+	defer c.Unlock()
+	if !c.isConnected || c.conn == nil || c.conn.IsClosed() {
+		return fmt.Errorf("rabbitmq connection is closed")
+	}
+	return nil
+}
+
 func (c *Client) Stop() error {
 	if c.channel != nil {
 		return c.channel.Close()
@@ -20,6 +20,7 @@ type metadata struct {
 	correlationId string
 	replyTo       string
 	expiration    time.Duration
+	headers       []types.MessageHeader
 }
 
 func parseMetadata(meta types.Metadata) (metadata, error) {
@@ -51,8 +52,12 @@ func parseMetadata(meta types.Metadata) (metadata, error) {
 }
 
 func (m metadata) amqpMessage(data []byte) amqp.Publishing {
+	headers := amqp.Table{}
+	for _, header := range m.headers {
+		headers[header.Key] = header.Value
+	}
 	return amqp.Publishing{
-		Headers:         amqp.Table{},
+		Headers:         headers,
 		ContentType:     "text/plain",
 		ContentEncoding: "",
 		DeliveryMode:    uint8(m.deliveryMode),
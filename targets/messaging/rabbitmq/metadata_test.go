@@ -0,0 +1,43 @@
+package rabbitmq
+
+import (
+	"testing"
+
+	"github.com/kubemq-io/kubemq-targets/types"
+	"github.com/streadway/amqp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetadata_amqpMessage(t *testing.T) {
+	tests := []struct {
+		name        string
+		meta        types.Metadata
+		wantHeaders amqp.Table
+	}{
+		{
+			name:        "no message attributes",
+			meta:        map[string]string{"queue": "some-queue"},
+			wantHeaders: amqp.Table{},
+		},
+		{
+			name: "message attributes",
+			meta: map[string]string{
+				"queue":                     "some-queue",
+				"kubemq.id":                 "id-1",
+				"kubemq.tag.correlation_id": "abc",
+			},
+			wantHeaders: amqp.Table{
+				"kubemq.id":                 "id-1",
+				"kubemq.tag.correlation_id": "abc",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meta, err := parseMetadata(tt.meta)
+			require.NoError(t, err)
+			meta.headers = tt.meta.MessageHeaders()
+			require.Equal(t, tt.wantHeaders, meta.amqpMessage([]byte("data")).Headers)
+		})
+	}
+}
@@ -56,7 +56,7 @@ func (c *Client) Init(ctx context.Context, cfg config.Spec, log *logger.Logger)
 func (c *Client) Do(ctx context.Context, req *types.Request) (*types.Response, error) {
 	meta, err := parseMetadata(req.Metadata)
 	if err != nil {
-		return nil, err
+		return nil, types.NewPermanentError(err)
 	}
 	switch meta.method {
 	case "get":
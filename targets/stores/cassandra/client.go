@@ -81,7 +81,7 @@ func (c *Client) tryCreateTable(table, keyspace string) error {
 func (c *Client) Do(ctx context.Context, req *types.Request) (*types.Response, error) {
 	meta, err := parseMetadata(req.Metadata)
 	if err != nil {
-		return nil, err
+		return nil, types.NewPermanentError(err)
 	}
 	switch meta.method {
 	case "get":
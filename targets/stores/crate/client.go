@@ -11,6 +11,7 @@ import (
 	"github.com/kubemq-hub/builder/connector/common"
 	"github.com/kubemq-io/kubemq-targets/config"
 	"github.com/kubemq-io/kubemq-targets/pkg/logger"
+	"github.com/kubemq-io/kubemq-targets/pkg/sqlerror"
 	"github.com/kubemq-io/kubemq-targets/types"
 	_ "github.com/lib/pq"
 )
@@ -61,16 +62,16 @@ func (c *Client) Init(ctx context.Context, cfg config.Spec, log *logger.Logger)
 func (c *Client) Do(ctx context.Context, req *types.Request) (*types.Response, error) {
 	meta, err := parseMetadata(req.Metadata)
 	if err != nil {
-		return nil, err
+		return nil, types.NewPermanentError(err)
 	}
+	var resp *types.Response
 	switch meta.method {
 	case "query":
-		return c.Query(ctx, meta, req.Data)
+		resp, err = c.Query(ctx, meta, req.Data)
 	case "exec":
-		return c.Exec(ctx, meta, req.Data)
+		resp, err = c.Exec(ctx, meta, req.Data)
 	}
-
-	return nil, nil
+	return resp, sqlerror.Classify(err)
 }
 
 func getStatements(data []byte) []string {
@@ -83,7 +84,7 @@ func getStatements(data []byte) []string {
 func (c *Client) Exec(ctx context.Context, meta metadata, value []byte) (*types.Response, error) {
 	stmts := getStatements(value)
 	if stmts == nil {
-		return nil, fmt.Errorf("no exec statement found")
+		return nil, types.NewPermanentError(fmt.Errorf("no exec statement found"))
 	}
 	for i, stmt := range stmts {
 		if stmt != "" {
@@ -101,7 +102,7 @@ func (c *Client) Exec(ctx context.Context, meta metadata, value []byte) (*types.
 func (c *Client) Query(ctx context.Context, meta metadata, value []byte) (*types.Response, error) {
 	stmt := string(value)
 	if stmt == "" {
-		return nil, fmt.Errorf("no query statement found")
+		return nil, types.NewPermanentError(fmt.Errorf("no query statement found"))
 	}
 	rows, err := c.db.QueryContext(ctx, stmt)
 	if err != nil {
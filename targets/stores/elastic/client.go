@@ -2,6 +2,7 @@ package elastic
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/kubemq-hub/builder/connector/common"
@@ -58,7 +59,7 @@ func (c *Client) Init(ctx context.Context, cfg config.Spec, log *logger.Logger)
 func (c *Client) Do(ctx context.Context, req *types.Request) (*types.Response, error) {
 	meta, err := parseMetadata(req.Metadata)
 	if err != nil {
-		return nil, err
+		return nil, types.NewPermanentError(err)
 	}
 	switch meta.method {
 	case "get":
@@ -145,3 +146,46 @@ func (c *Client) IndexDelete(ctx context.Context, meta metadata) (*types.Respons
 func (c *Client) Stop() error {
 	return nil
 }
+
+func (c *Client) DoBatch(ctx context.Context, requests []*types.Request) ([]*types.Response, []error) {
+	responses := make([]*types.Response, len(requests))
+	errs := make([]error, len(requests))
+	bulk := c.elastic.Bulk()
+	var bulkIndexes []int
+	for i, req := range requests {
+		meta, err := parseMetadata(req.Metadata)
+		if err != nil {
+			errs[i] = types.NewPermanentError(err)
+			continue
+		}
+		if meta.method != "set" {
+			responses[i], errs[i] = c.Do(ctx, req)
+			continue
+		}
+		bulk.Add(elastic.NewBulkIndexRequest().Index(meta.index).Id(meta.id).Doc(json.RawMessage(req.Data)))
+		bulkIndexes = append(bulkIndexes, i)
+	}
+	if len(bulkIndexes) == 0 {
+		return responses, errs
+	}
+	bulkResp, err := bulk.Do(ctx)
+	if err == nil && len(bulkResp.Items) != len(bulkIndexes) {
+		err = fmt.Errorf("bulk response items count %d does not match requests count %d", len(bulkResp.Items), len(bulkIndexes))
+	}
+	for n, i := range bulkIndexes {
+		if err != nil {
+			errs[i] = fmt.Errorf("failed to execute bulk set: %w", err)
+			continue
+		}
+		for _, item := range bulkResp.Items[n] {
+			if item.Error != nil {
+				errs[i] = fmt.Errorf("failed to set document id %s: %s", item.Id, item.Error.Reason)
+				continue
+			}
+			responses[i] = types.NewResponse().
+				SetMetadataKeyValue("id", item.Id).
+				SetMetadataKeyValue("result", item.Result)
+		}
+	}
+	return responses, errs
+}
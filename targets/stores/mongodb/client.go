@@ -134,7 +134,7 @@ func (c *Client) getMongoDBClient(ctx context.Context) (*mongo.Client, error) {
 func (c *Client) Do(ctx context.Context, req *types.Request) (*types.Response, error) {
 	meta, err := parseMetadata(req.Metadata)
 	if err != nil {
-		return nil, err
+		return nil, types.NewPermanentError(err)
 	}
 	switch meta.method {
 	case "get_by_key":
@@ -438,6 +438,72 @@ func (c *Client) Delete(ctx context.Context, meta metadata) (*types.Response, er
 		nil
 }
 
+func (c *Client) DoBatch(ctx context.Context, requests []*types.Request) ([]*types.Response, []error) {
+	responses := make([]*types.Response, len(requests))
+	errs := make([]error, len(requests))
+	var docs []interface{}
+	var indexes []int
+	for i, req := range requests {
+		meta, err := parseMetadata(req.Metadata)
+		if err != nil {
+			errs[i] = types.NewPermanentError(err)
+			continue
+		}
+		if meta.method != "insert" {
+			responses[i], errs[i] = c.Do(ctx, req)
+			continue
+		}
+		var doc interface{}
+		err = json.Unmarshal(req.Data, &doc)
+		if err != nil {
+			errs[i] = fmt.Errorf("insert document json parsing error, %s", err.Error())
+			continue
+		}
+		docs = append(docs, doc)
+		indexes = append(indexes, i)
+	}
+	if len(docs) == 0 {
+		return responses, errs
+	}
+	ctx, cancel := context.WithTimeout(ctx, c.opts.operationTimeout)
+	defer cancel()
+	results, err := c.collection.InsertMany(ctx, docs, monogOptions.InsertMany().SetOrdered(false))
+	docErrs := map[int]error{}
+	if bulkErr, ok := err.(mongo.BulkWriteException); ok {
+		for _, writeErr := range bulkErr.WriteErrors {
+			docErrs[writeErr.Index] = fmt.Errorf("insert error, %s", writeErr.Message)
+		}
+		err = nil
+	}
+	for n, i := range indexes {
+		if err != nil {
+			errs[i] = fmt.Errorf("insert error, %s", err.Error())
+			continue
+		}
+		if docErr, ok := docErrs[n]; ok {
+			errs[i] = docErr
+			continue
+		}
+		response := types.NewResponse().
+			SetMetadataKeyValue("result", "ok")
+		if results != nil && n < len(results.InsertedIDs) {
+			data, err := json.Marshal(results.InsertedIDs[n])
+			if err != nil {
+				errs[i] = fmt.Errorf("insert result json parsing error, %s", err.Error())
+				continue
+			}
+			response.SetData(data)
+		}
+		responses[i] = response
+	}
+	return responses, errs
+}
+
+// Check pings the mongodb server
+func (c *Client) Check(ctx context.Context) error {
+	return c.client.Ping(ctx, nil)
+}
+
 func (c *Client) Stop() error {
 	return c.client.Disconnect(context.Background())
 }
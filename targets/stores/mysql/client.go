@@ -13,6 +13,7 @@ import (
 	"github.com/kubemq-hub/builder/connector/common"
 	"github.com/kubemq-io/kubemq-targets/config"
 	"github.com/kubemq-io/kubemq-targets/pkg/logger"
+	"github.com/kubemq-io/kubemq-targets/pkg/sqlerror"
 	"github.com/kubemq-io/kubemq-targets/types"
 )
 
@@ -62,24 +63,24 @@ func (c *Client) Init(ctx context.Context, cfg config.Spec, log *logger.Logger)
 func (c *Client) Do(ctx context.Context, req *types.Request) (*types.Response, error) {
 	meta, err := parseMetadata(req.Metadata)
 	if err != nil {
-		return nil, err
+		return nil, types.NewPermanentError(err)
 	}
+	var resp *types.Response
 	switch meta.method {
 	case "query":
-		return c.Query(ctx, meta, req.Data)
+		resp, err = c.Query(ctx, meta, req.Data)
 	case "exec":
-		return c.Exec(ctx, meta, req.Data)
+		resp, err = c.Exec(ctx, meta, req.Data)
 	case "transaction":
-		return c.Transaction(ctx, meta, req.Data)
+		resp, err = c.Transaction(ctx, meta, req.Data)
 	}
-
-	return nil, nil
+	return resp, sqlerror.Classify(err)
 }
 
 func (c *Client) Exec(ctx context.Context, meta metadata, value []byte) (*types.Response, error) {
 	stmts := getStatements(value)
 	if stmts == nil {
-		return nil, fmt.Errorf("no exec statement found")
+		return nil, types.NewPermanentError(fmt.Errorf("no exec statement found"))
 	}
 	for i, stmt := range stmts {
 		if stmt != "" {
@@ -105,7 +106,7 @@ func getStatements(data []byte) []string {
 func (c *Client) Transaction(ctx context.Context, meta metadata, value []byte) (*types.Response, error) {
 	stmts := getStatements(value)
 	if stmts == nil {
-		return nil, fmt.Errorf("no transaction statements found")
+		return nil, types.NewPermanentError(fmt.Errorf("no transaction statements found"))
 	}
 
 	tx, err := c.db.BeginTx(ctx, &sql.TxOptions{
@@ -145,7 +146,7 @@ func (c *Client) Transaction(ctx context.Context, meta metadata, value []byte) (
 func (c *Client) Query(ctx context.Context, meta metadata, value []byte) (*types.Response, error) {
 	stmt := string(value)
 	if stmt == "" {
-		return nil, fmt.Errorf("no query statement found")
+		return nil, types.NewPermanentError(fmt.Errorf("no query statement found"))
 	}
 	rows, err := c.db.QueryContext(ctx, stmt)
 	if err != nil {
@@ -207,6 +208,11 @@ func parseWithRawBytes(rows *sql.Rows, cols []string, colsTypes []*sql.ColumnTyp
 	return m
 }
 
+// Check pings the database
+func (c *Client) Check(ctx context.Context) error {
+	return c.db.PingContext(ctx)
+}
+
 func (c *Client) Stop() error {
 	if c.db != nil {
 		return c.db.Close()
@@ -11,6 +11,7 @@ import (
 	"github.com/kubemq-hub/builder/connector/common"
 	"github.com/kubemq-io/kubemq-targets/config"
 	"github.com/kubemq-io/kubemq-targets/pkg/logger"
+	"github.com/kubemq-io/kubemq-targets/pkg/sqlerror"
 	"github.com/kubemq-io/kubemq-targets/types"
 	_ "github.com/lib/pq"
 )
@@ -61,18 +62,18 @@ func (c *Client) Init(ctx context.Context, cfg config.Spec, log *logger.Logger)
 func (c *Client) Do(ctx context.Context, req *types.Request) (*types.Response, error) {
 	meta, err := parseMetadata(req.Metadata)
 	if err != nil {
-		return nil, err
+		return nil, types.NewPermanentError(err)
 	}
+	var resp *types.Response
 	switch meta.method {
 	case "query":
-		return c.Query(ctx, meta, req.Data)
+		resp, err = c.Query(ctx, meta, req.Data)
 	case "exec":
-		return c.Exec(ctx, meta, req.Data)
+		resp, err = c.Exec(ctx, meta, req.Data)
 	case "transaction":
-		return c.Transaction(ctx, meta, req.Data)
+		resp, err = c.Transaction(ctx, meta, req.Data)
 	}
-
-	return nil, nil
+	return resp, sqlerror.Classify(err)
 }
 
 func getStatements(data []byte) []string {
@@ -85,7 +86,7 @@ func getStatements(data []byte) []string {
 func (c *Client) Exec(ctx context.Context, meta metadata, value []byte) (*types.Response, error) {
 	stmts := getStatements(value)
 	if stmts == nil {
-		return nil, fmt.Errorf("no exec statement found")
+		return nil, types.NewPermanentError(fmt.Errorf("no exec statement found"))
 	}
 	for i, stmt := range stmts {
 		if stmt != "" {
@@ -103,7 +104,7 @@ func (c *Client) Exec(ctx context.Context, meta metadata, value []byte) (*types.
 func (c *Client) Transaction(ctx context.Context, meta metadata, value []byte) (*types.Response, error) {
 	stmts := getStatements(value)
 	if stmts == nil {
-		return nil, fmt.Errorf("no transaction statements found")
+		return nil, types.NewPermanentError(fmt.Errorf("no transaction statements found"))
 	}
 
 	tx, err := c.db.BeginTx(ctx, &sql.TxOptions{
@@ -143,7 +144,7 @@ func (c *Client) Transaction(ctx context.Context, meta metadata, value []byte) (
 func (c *Client) Query(ctx context.Context, meta metadata, value []byte) (*types.Response, error) {
 	stmt := string(value)
 	if stmt == "" {
-		return nil, fmt.Errorf("no query statement found")
+		return nil, types.NewPermanentError(fmt.Errorf("no query statement found"))
 	}
 	rows, err := c.db.QueryContext(ctx, stmt)
 	if err != nil {
@@ -190,6 +191,79 @@ func parseToMap(rows *sql.Rows, cols []string) map[string]interface{} {
 	return m
 }
 
+func (c *Client) DoBatch(ctx context.Context, requests []*types.Request) ([]*types.Response, []error) {
+	responses := make([]*types.Response, len(requests))
+	errs := make([]error, len(requests))
+	var indexes []int
+	for i, req := range requests {
+		meta, err := parseMetadata(req.Metadata)
+		if err != nil {
+			errs[i] = types.NewPermanentError(err)
+			continue
+		}
+		if meta.method != "exec" {
+			responses[i], errs[i] = c.Do(ctx, req)
+			continue
+		}
+		if getStatements(req.Data) == nil {
+			errs[i] = types.NewPermanentError(fmt.Errorf("no exec statement found"))
+			continue
+		}
+		indexes = append(indexes, i)
+	}
+	if len(indexes) == 0 {
+		return responses, errs
+	}
+	if err := sqlerror.Classify(c.execBatch(ctx, requests, indexes)); err != nil {
+		if !types.IsPermanentError(err) {
+			// a connection or server error fails the other requests as well, they are retried as a whole
+			for _, i := range indexes {
+				errs[i] = err
+			}
+			return responses, errs
+		}
+		// the failed statement rolled back the whole batch, each request is executed on its own so only the failed ones fail
+		c.log.Errorf("batch of %d exec requests failed, executing requests one by one, %s", len(indexes), err.Error())
+		for _, i := range indexes {
+			responses[i], errs[i] = c.Do(ctx, requests[i])
+		}
+		return responses, errs
+	}
+	for _, i := range indexes {
+		responses[i] = types.NewResponse().
+			SetMetadataKeyValue("result", "ok")
+	}
+	return responses, errs
+}
+
+func (c *Client) execBatch(ctx context.Context, requests []*types.Request, indexes []int) error {
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	for _, i := range indexes {
+		for n, stmt := range getStatements(requests[i].Data) {
+			if stmt == "" {
+				continue
+			}
+			_, err := tx.ExecContext(ctx, stmt)
+			if err != nil {
+				err = fmt.Errorf("error on batch request %d statement %d, %w", i, n, err)
+				if rollBackErr := tx.Rollback(); rollBackErr != nil {
+					return fmt.Errorf("%w, rollback error: %s", err, rollBackErr.Error())
+				}
+				return err
+			}
+		}
+	}
+	return tx.Commit()
+}
+
+// Check pings the database
+func (c *Client) Check(ctx context.Context) error {
+	return c.db.PingContext(ctx)
+}
+
 func (c *Client) Stop() error {
 	if c.db != nil {
 		return c.db.Close()
@@ -2,13 +2,22 @@ package types
 
 import "time"
 
+const (
+	ActivityStatusSuccess = "success"
+	ActivityStatusError   = "error"
+)
+
 type Activity struct {
-	Id        int64  `storm:"id,increment"`
-	Binding   string `storm:"index"`
-	CreatedAt time.Time
-	Request   *Request
-	Response  *Response
-	Error     error
+	Id         int64     `json:"id" storm:"id,increment"`
+	Binding    string    `json:"binding" storm:"index"`
+	CreatedAt  time.Time `json:"created_at"`
+	DurationMs float64   `json:"duration_ms"`
+	Status     string    `json:"status"`
+	Request    *Request  `json:"request"`
+	Response   *Response `json:"response,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	ReplayOf   int64     `json:"replay_of,omitempty"`
+	Redacted   bool      `json:"redacted,omitempty"`
 }
 
 func NewActivity() *Activity {
@@ -17,6 +26,17 @@ func NewActivity() *Activity {
 	}
 }
 
-//func (a *Activity) SetBindings  {
-//
-//}
+// SetResult sets the activity status, response, error and duration since its creation
+func (a *Activity) SetResult(response *Response, err error) *Activity {
+	a.DurationMs = float64(time.Since(a.CreatedAt).Microseconds()) / 1000
+	a.Response = response
+	a.Status = ActivityStatusSuccess
+	if err != nil {
+		a.Status = ActivityStatusError
+		a.Error = err.Error()
+	} else if response != nil && response.IsError {
+		a.Status = ActivityStatusError
+		a.Error = response.Error
+	}
+	return a
+}
@@ -0,0 +1,62 @@
+package types
+
+import (
+	"fmt"
+	"time"
+)
+
+type DeadLetter struct {
+	Binding   string   `json:"binding"`
+	Request   *Request `json:"request"`
+	Error     string   `json:"error"`
+	Attempts  int      `json:"attempts"`
+	Timestamp int64    `json:"timestamp"`
+}
+
+func NewDeadLetter() *DeadLetter {
+	return &DeadLetter{
+		Timestamp: time.Now().UnixNano(),
+	}
+}
+
+func (d *DeadLetter) SetBinding(value string) *DeadLetter {
+	d.Binding = value
+	return d
+}
+
+func (d *DeadLetter) SetRequest(value *Request) *DeadLetter {
+	d.Request = value
+	return d
+}
+
+func (d *DeadLetter) SetError(err error) *DeadLetter {
+	if err != nil {
+		d.Error = err.Error()
+	}
+	return d
+}
+
+func (d *DeadLetter) SetAttempts(value int) *DeadLetter {
+	d.Attempts = value
+	return d
+}
+
+func (d *DeadLetter) MarshalBinary() []byte {
+	data, _ := json.Marshal(d)
+	return data
+}
+
+func ParseDeadLetter(body []byte) (*DeadLetter, error) {
+	if body == nil {
+		return nil, fmt.Errorf("empty dead letter")
+	}
+	d := &DeadLetter{}
+	err := json.Unmarshal(body, d)
+	if err != nil {
+		return nil, fmt.Errorf("invalid dead letter format, %w", err)
+	}
+	if d.Request == nil {
+		return nil, fmt.Errorf("invalid dead letter format, no request found")
+	}
+	return d, nil
+}
@@ -0,0 +1,97 @@
+package types
+
+import (
+	"errors"
+	"strconv"
+	"time"
+)
+
+type ErrorClass string
+
+const (
+	ErrorClassTransient ErrorClass = "transient"
+	ErrorClassPermanent ErrorClass = "permanent"
+	ErrorClassThrottled ErrorClass = "throttled"
+)
+
+// Error is a classified error a target can return to let the binding
+// middlewares decide whether and when the request should be retried.
+type Error struct {
+	Class      ErrorClass
+	RetryAfter time.Duration
+	Err        error
+}
+
+func (e *Error) Error() string {
+	if e.Err == nil {
+		return string(e.Class)
+	}
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func NewTransientError(err error) error {
+	return &Error{
+		Class: ErrorClassTransient,
+		Err:   err,
+	}
+}
+
+func NewPermanentError(err error) error {
+	return &Error{
+		Class: ErrorClassPermanent,
+		Err:   err,
+	}
+}
+
+func NewThrottledError(err error, retryAfter time.Duration) error {
+	return &Error{
+		Class:      ErrorClassThrottled,
+		RetryAfter: retryAfter,
+		Err:        err,
+	}
+}
+
+// ErrorClassOf returns the class of err, unclassified errors are considered transient
+func ErrorClassOf(err error) ErrorClass {
+	var classified *Error
+	if errors.As(err, &classified) {
+		return classified.Class
+	}
+	return ErrorClassTransient
+}
+
+func IsPermanentError(err error) bool {
+	return err != nil && ErrorClassOf(err) == ErrorClassPermanent
+}
+
+// RetryAfterOf returns the retry hint of a throttled error, or zero if none was given
+func RetryAfterOf(err error) time.Duration {
+	var classified *Error
+	if errors.As(err, &classified) && classified.Class == ErrorClassThrottled {
+		return classified.RetryAfter
+	}
+	return 0
+}
+
+// ParseRetryAfter parses an HTTP Retry-After header value, either delay seconds or an HTTP date
+func ParseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := time.Parse(time.RFC1123, value); err == nil {
+		if d := time.Until(at); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
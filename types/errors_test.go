@@ -0,0 +1,55 @@
+package types
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrors_Class(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		wantClass      ErrorClass
+		wantRetryAfter time.Duration
+	}{
+		{
+			name:      "unclassified error",
+			err:       fmt.Errorf("some-error"),
+			wantClass: ErrorClassTransient,
+		},
+		{
+			name:      "permanent error",
+			err:       NewPermanentError(fmt.Errorf("some-error")),
+			wantClass: ErrorClassPermanent,
+		},
+		{
+			name:      "wrapped permanent error",
+			err:       fmt.Errorf("wrapped, %w", NewPermanentError(fmt.Errorf("some-error"))),
+			wantClass: ErrorClassPermanent,
+		},
+		{
+			name:           "throttled error",
+			err:            NewThrottledError(fmt.Errorf("some-error"), time.Second),
+			wantClass:      ErrorClassThrottled,
+			wantRetryAfter: time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.wantClass, ErrorClassOf(tt.err))
+			require.Equal(t, tt.wantRetryAfter, RetryAfterOf(tt.err))
+			require.Contains(t, tt.err.Error(), "some-error")
+		})
+	}
+}
+
+func TestErrors_ParseRetryAfter(t *testing.T) {
+	require.Equal(t, 120*time.Second, ParseRetryAfter("120"))
+	require.Equal(t, time.Duration(0), ParseRetryAfter(""))
+	require.Equal(t, time.Duration(0), ParseRetryAfter("-1"))
+	require.Equal(t, time.Duration(0), ParseRetryAfter("bad-value"))
+	require.Greater(t, ParseRetryAfter(time.Now().Add(time.Minute).UTC().Format(time.RFC1123)), 50*time.Second)
+}
@@ -0,0 +1,145 @@
+package types
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Metadata keys with the reserved prefix carry the KubeMQ message attributes of the source message
+const (
+	ReservedMetadataPrefix = "kubemq."
+	TagMetadataPrefix      = ReservedMetadataPrefix + "tag."
+	MessageIdKey           = ReservedMetadataPrefix + "id"
+	MessageChannelKey      = ReservedMetadataPrefix + "channel"
+	MessageClientIdKey     = ReservedMetadataPrefix + "client_id"
+	MessageMetadataKey     = ReservedMetadataPrefix + "metadata"
+	MessageTimestampKey    = ReservedMetadataPrefix + "timestamp"
+	MessageSequenceKey     = ReservedMetadataPrefix + "sequence"
+	MessageReceiveCountKey = ReservedMetadataPrefix + "receive_count"
+)
+
+type MessageContext struct {
+	Id           string
+	Channel      string
+	ClientId     string
+	Metadata     string
+	Timestamp    time.Time
+	Sequence     uint64
+	ReceiveCount int
+	Tags         map[string]string
+}
+
+func (mc *MessageContext) metadata() Metadata {
+	m := NewMetadata()
+	setIfNotEmpty := func(key, value string) {
+		if value != "" {
+			m[key] = value
+		}
+	}
+	setIfNotEmpty(MessageIdKey, mc.Id)
+	setIfNotEmpty(MessageChannelKey, mc.Channel)
+	setIfNotEmpty(MessageClientIdKey, mc.ClientId)
+	setIfNotEmpty(MessageMetadataKey, mc.Metadata)
+	if !mc.Timestamp.IsZero() {
+		m[MessageTimestampKey] = mc.Timestamp.UTC().Format(time.RFC3339Nano)
+	}
+	if mc.Sequence > 0 {
+		m[MessageSequenceKey] = strconv.FormatUint(mc.Sequence, 10)
+	}
+	if mc.ReceiveCount > 0 {
+		m[MessageReceiveCountKey] = strconv.Itoa(mc.ReceiveCount)
+	}
+	for key, value := range mc.Tags {
+		m[TagMetadataPrefix+key] = value
+	}
+	return m
+}
+
+// SetMessageContext sets the source message attributes under the reserved metadata prefix, replacing any reserved keys sent in the request
+func (r *Request) SetMessageContext(mc *MessageContext) *Request {
+	if r.Metadata == nil {
+		r.Metadata = NewMetadata()
+	}
+	for key := range r.Metadata {
+		if strings.HasPrefix(key, ReservedMetadataPrefix) {
+			delete(r.Metadata, key)
+		}
+	}
+	for key, value := range mc.metadata() {
+		r.Metadata[key] = value
+	}
+	return r
+}
+
+// Tags returns the source message tags carried in the request metadata
+func (r *Request) Tags() map[string]string {
+	return r.Metadata.tags()
+}
+
+// MessageHeader is a source message attribute or tag sent as a header or attribute of the target message
+type MessageHeader struct {
+	Key   string
+	Value string
+}
+
+// MessageHeaders returns the source message attributes and tags of the request metadata keyed by their reserved metadata key,
+// the tags first and each group sorted by key, so targets limiting the number of headers keep the tags
+func (m Metadata) MessageHeaders() []MessageHeader {
+	var tags, attributes []MessageHeader
+	for key, value := range m {
+		switch {
+		case strings.HasPrefix(key, TagMetadataPrefix):
+			tags = append(tags, MessageHeader{Key: key, Value: value})
+		case strings.HasPrefix(key, ReservedMetadataPrefix):
+			attributes = append(attributes, MessageHeader{Key: key, Value: value})
+		}
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Key < tags[j].Key })
+	sort.Slice(attributes, func(i, j int) bool { return attributes[i].Key < attributes[j].Key })
+	return append(tags, attributes...)
+}
+
+// SetTags sets tags to be added to the response message sent by the source
+func (r *Response) SetTags(tags map[string]string) *Response {
+	if r.Metadata == nil {
+		r.Metadata = NewMetadata()
+	}
+	for key, value := range tags {
+		r.Metadata[TagMetadataPrefix+key] = value
+	}
+	return r
+}
+
+// MergeTags sets tags missing in the response, keeping tags already set by the target
+func (r *Response) MergeTags(tags map[string]string) *Response {
+	if r == nil {
+		return r
+	}
+	current := r.Tags()
+	for key, value := range tags {
+		if _, ok := current[key]; !ok {
+			r.SetTags(map[string]string{key: value})
+		}
+	}
+	return r
+}
+
+// Tags returns the response tags set under the reserved tag prefix
+func (r *Response) Tags() map[string]string {
+	if r == nil {
+		return map[string]string{}
+	}
+	return r.Metadata.tags()
+}
+
+func (m Metadata) tags() map[string]string {
+	tags := map[string]string{}
+	for key, value := range m {
+		if strings.HasPrefix(key, TagMetadataPrefix) {
+			tags[strings.TrimPrefix(key, TagMetadataPrefix)] = value
+		}
+	}
+	return tags
+}
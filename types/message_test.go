@@ -0,0 +1,128 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequest_SetMessageContext(t *testing.T) {
+	ts := time.Date(2021, 1, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		req  *Request
+		mc   *MessageContext
+		want Metadata
+	}{
+		{
+			name: "full context",
+			req:  NewRequest().SetMetadataKeyValue("method", "get"),
+			mc: &MessageContext{
+				Id:           "id-1",
+				Channel:      "orders",
+				ClientId:     "client-1",
+				Metadata:     "some-metadata",
+				Timestamp:    ts,
+				Sequence:     10,
+				ReceiveCount: 2,
+				Tags:         map[string]string{"correlation_id": "abc"},
+			},
+			want: Metadata{
+				"method":                    "get",
+				"kubemq.id":                 "id-1",
+				"kubemq.channel":            "orders",
+				"kubemq.client_id":          "client-1",
+				"kubemq.metadata":           "some-metadata",
+				"kubemq.timestamp":          "2021-01-01T10:00:00Z",
+				"kubemq.sequence":           "10",
+				"kubemq.receive_count":      "2",
+				"kubemq.tag.correlation_id": "abc",
+			},
+		},
+		{
+			name: "reserved keys are replaced",
+			req: NewRequest().
+				SetMetadataKeyValue("method", "get").
+				SetMetadataKeyValue("kubemq.id", "fake-id").
+				SetMetadataKeyValue("kubemq.tag.user", "fake-user"),
+			mc: &MessageContext{
+				Id:      "id-1",
+				Channel: "orders",
+			},
+			want: Metadata{
+				"method":         "get",
+				"kubemq.id":      "id-1",
+				"kubemq.channel": "orders",
+			},
+		},
+		{
+			name: "nil request metadata",
+			req:  &Request{},
+			mc: &MessageContext{
+				Id: "id-1",
+			},
+			want: Metadata{
+				"kubemq.id": "id-1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.req.SetMessageContext(tt.mc)
+			require.Equal(t, tt.want, got.Metadata)
+		})
+	}
+}
+
+func TestResponse_Tags(t *testing.T) {
+	resp := NewResponse().
+		SetMetadataKeyValue("result", "ok").
+		SetTags(map[string]string{"status": "done"})
+	resp.MergeTags(map[string]string{"status": "pending", "correlation_id": "abc"})
+	require.Equal(t, map[string]string{"status": "done", "correlation_id": "abc"}, resp.Tags())
+	require.Equal(t, "ok", resp.Metadata.Get("result"))
+	require.Equal(t, resp.Tags(), resp.ToEvent().Tags)
+	require.Equal(t, resp.Tags(), resp.ToResponse().Tags)
+
+	var nilResp *Response
+	require.Nil(t, nilResp.MergeTags(map[string]string{"a": "b"}))
+	require.Empty(t, nilResp.Tags())
+
+	req := NewRequest().SetMessageContext(&MessageContext{Tags: map[string]string{"a": "b"}})
+	require.Equal(t, map[string]string{"a": "b"}, req.Tags())
+}
+
+func TestMetadata_MessageHeaders(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *Request
+		want []MessageHeader
+	}{
+		{
+			name: "no reserved keys",
+			req:  NewRequest().SetMetadataKeyValue("method", "get"),
+			want: nil,
+		},
+		{
+			name: "tags first",
+			req: NewRequest().
+				SetMetadataKeyValue("method", "get").
+				SetMetadataKeyValue("kubemq.id", "id-1").
+				SetMetadataKeyValue("kubemq.channel", "orders").
+				SetMetadataKeyValue("kubemq.tag.user", "user-1").
+				SetMetadataKeyValue("kubemq.tag.correlation_id", "abc"),
+			want: []MessageHeader{
+				{Key: "kubemq.tag.correlation_id", Value: "abc"},
+				{Key: "kubemq.tag.user", Value: "user-1"},
+				{Key: "kubemq.channel", Value: "orders"},
+				{Key: "kubemq.id", Value: "id-1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.req.Metadata.MessageHeaders())
+		})
+	}
+}
@@ -49,39 +49,46 @@ func (r *Response) MarshalBinary() []byte {
 
 func (r *Response) ToEvent() *kubemq.Event {
 	return kubemq.NewEvent().
+		SetTags(r.Tags()).
 		SetBody(r.MarshalBinary())
 }
 
 func (r *Response) ToEventStore() *kubemq.EventStore {
 	return kubemq.NewEventStore().
+		SetTags(r.Tags()).
 		SetBody(r.MarshalBinary())
 }
 
 func (r *Response) ToCommand() *kubemq.Command {
 	return kubemq.NewCommand().
 		SetMetadata(r.Metadata.String()).
+		SetTags(r.Tags()).
 		SetBody(r.Data)
 }
 
 func (r *Response) ToQuery() *kubemq.Query {
 	return kubemq.NewQuery().
 		SetMetadata(r.Metadata.String()).
+		SetTags(r.Tags()).
 		SetBody(r.Data)
 }
 
 func (r *Response) ToQueueMessage() *kubemq.QueueMessage {
 	return kubemq.NewQueueMessage().
+		SetTags(r.Tags()).
 		SetBody(r.MarshalBinary())
 }
 
 func (r *Response) ToQueueStreamMessage() *queues_stream.QueueMessage {
 	return queues_stream.NewQueueMessage().
+		SetTags(r.Tags()).
 		SetBody(r.MarshalBinary())
 }
 
 func (r *Response) ToResponse() *kubemq.Response {
 	return kubemq.NewResponse().
 		SetMetadata(r.Metadata.String()).
+		SetTags(r.Tags()).
 		SetBody(r.Data)
 }
 